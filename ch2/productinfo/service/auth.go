@@ -0,0 +1,51 @@
+package main
+
+import (
+	"log"
+
+	"productinfo/service/pkg/auth"
+	pb "productinfo/service/protos/product_info/v1"
+)
+
+const (
+	scopeProductsRead  = "products:read"
+	scopeProductsWrite = "products:write"
+)
+
+// productPolicy lists the scopes each ProductInfoService method requires.
+var productPolicy = auth.Policy{
+	Scopes: map[string][]string{
+		pb.ProductInfoService_AddProduct_FullMethodName:    {scopeProductsWrite},
+		pb.ProductInfoService_UpdateProduct_FullMethodName: {scopeProductsWrite},
+		pb.ProductInfoService_DeleteProduct_FullMethodName: {scopeProductsWrite},
+		pb.ProductInfoService_GetProduct_FullMethodName:    {scopeProductsRead},
+		pb.ProductInfoService_ListProducts_FullMethodName:  {scopeProductsRead},
+	},
+	Public: healthMethods,
+}
+
+// newAuthenticator builds the authenticator of the API keys and JWTs
+// enabled with the -api-keys and -jwt-key-file flags. It returns nil when
+// neither is set, leaving the server open to any caller.
+func newAuthenticator() (auth.Authenticator, error) {
+	var authenticators auth.Authenticators
+	if *apiKeysFile != "" {
+		keys, err := auth.LoadAPIKeys(*apiKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, keys)
+	}
+	if *jwtKeyFile != "" {
+		key, err := auth.LoadJWTKey(*jwtKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, &auth.JWTs{Key: key, Issuer: *jwtIssuer})
+	}
+	if len(authenticators) == 0 {
+		log.Print("Authentication is disabled, set -api-keys or -jwt-key-file to enable it")
+		return nil, nil
+	}
+	return authenticators, nil
+}
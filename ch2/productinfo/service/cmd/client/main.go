@@ -2,21 +2,43 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"time"
 
+	"productinfo/service/pkg/tlsconfig"
 	pb "productinfo/service/protos/product_info/v1"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 const (
 	address = "localhost:50051"
 )
 
+var (
+	token    = flag.String("token", "", "bearer token, an API key or a JWT, sent with every RPC")
+	caFile   = flag.String("ca", "", "PEM CAs of the server certificate, enables TLS")
+	certFile = flag.String("cert", "", "PEM client certificate for mutual TLS")
+	keyFile  = flag.String("key", "", "PEM key of the client certificate")
+)
+
 func main() {
-	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	flag.Parse()
+
+	creds := insecure.NewCredentials()
+	if *caFile != "" {
+		cfg, err := tlsconfig.Client(tlsconfig.ClientOptions{CAFile: *caFile, CertFile: *certFile, KeyFile: *keyFile})
+		if err != nil {
+			log.Fatalf("failed to set up TLS: %v", err)
+		}
+		creds = credentials.NewTLS(cfg)
+	}
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -29,6 +51,9 @@ func main() {
 	price := float32(699.00)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
+	if *token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+	}
 	r, err := c.AddProduct(ctx, &pb.Product{Name: name, Description: description, Price: price})
 	if err != nil {
 		log.Fatalf("Could not add product: %v", err)
@@ -40,4 +65,33 @@ func main() {
 		log.Fatalf("Could not get product: %v", err)
 	}
 	log.Printf("Product: %v", product.String())
+
+	updated, err := c.UpdateProduct(ctx, &pb.UpdateProductRequest{
+		Product:    &pb.Product{Id: r.Value, Price: float32(599.00)},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price"}},
+	})
+	if err != nil {
+		log.Fatalf("Could not update product: %v", err)
+	}
+	log.Printf("Updated product: %v", updated.String())
+
+	listReq := &pb.ListProductsRequest{PageSize: 10, NameContains: "iphone"}
+	for {
+		page, err := c.ListProducts(ctx, listReq)
+		if err != nil {
+			log.Fatalf("Could not list products: %v", err)
+		}
+		for _, p := range page.Products {
+			log.Printf("Listed product: %v", p.String())
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		listReq.PageToken = page.NextPageToken
+	}
+
+	if _, err := c.DeleteProduct(ctx, &pb.ProductID{Value: r.Value}); err != nil {
+		log.Fatalf("Could not delete product: %v", err)
+	}
+	log.Printf("Product ID: %s deleted successfully", r.Value)
 }
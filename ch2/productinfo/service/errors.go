@@ -0,0 +1,29 @@
+package main
+
+import (
+	"errors"
+	"log"
+
+	"productinfo/service/pkg/rpcerr"
+	"productinfo/service/pkg/store"
+
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+const productResourceType = "ecommerce.v1.Product"
+
+// invalidArgumentError builds an InvalidArgument status carrying
+// a BadRequest with the given field violations.
+func invalidArgumentError(msg string, violations ...*epb.BadRequest_FieldViolation) error {
+	return rpcerr.InvalidArgument(msg, violations...)
+}
+
+// storeError converts a ProductStore error into a gRPC status.
+func storeError(err error, id string) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return rpcerr.NotFound(rpcerr.ProductNotFound, productResourceType, id)
+	}
+	log.Printf("product store error: %v", err)
+	return rpcerr.New(codes.Internal, rpcerr.Internal, "product store failure")
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"productinfo/service/pkg/store"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthCheckInterval is how often the health of the product store is checked.
+const healthCheckInterval = 5 * time.Second
+
+// healthMethods are the methods of the grpc.health.v1 service, open to any
+// caller so that orchestrators and load balancers can probe the server.
+var healthMethods = []string{
+	healthpb.Health_Check_FullMethodName,
+	healthpb.Health_Watch_FullMethodName,
+}
+
+// reportHealth sets the serving status of service and of the server as
+// a whole ("") from the health of products, then keeps it up to date in the
+// background, checking products every healthCheckInterval until ctx is done.
+// Stores that can't report their health are deemed healthy.
+func reportHealth(ctx context.Context, hs *health.Server, service string, products store.ProductStore) {
+	checker, _ := products.(store.HealthChecker)
+	var lastErr error
+	check := func() {
+		status := healthpb.HealthCheckResponse_SERVING
+		var err error
+		if checker != nil {
+			err = checker.Check(ctx)
+		}
+		if err != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			if lastErr == nil {
+				log.Printf("Product store is unhealthy, not serving: %v", err)
+			}
+		} else if lastErr != nil {
+			log.Print("Product store is healthy again, serving")
+		}
+		lastErr = err
+		hs.SetServingStatus(service, status)
+		hs.SetServingStatus("", status)
+	}
+
+	check()
+	go func() {
+		ticker := time.NewTicker(healthCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				check()
+			}
+		}
+	}()
+}
@@ -2,58 +2,297 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"productinfo/service/pkg/idempotency"
+	"productinfo/service/pkg/rpcerr"
+	"productinfo/service/pkg/server/interceptors"
+	"productinfo/service/pkg/store"
+	"productinfo/service/pkg/tracing"
 	pb "productinfo/service/protos/product_info/v1"
+	"syscall"
+	"time"
 
 	"github.com/gofrs/uuid"
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	port            = flag.String("port", ":50051", "address to listen on")
+	traceExporter   = flag.String("trace-exporter", "none", "exporter of the OpenTelemetry spans: none or stdout")
+	metricsAddress  = flag.String("metrics-address", "localhost:9091", "address of the Prometheus /metrics endpoint, empty to disable it")
+	idempotencyTTL  = flag.Duration("idempotency-ttl", time.Hour, "how long AddProduct responses are kept for requests with an idempotency key")
+	shutdownTimeout = flag.Duration("shutdown-timeout", 10*time.Second, "how long to wait for the RPCs in flight on shutdown before cancelling them")
+	debugErrors     = flag.Bool("debug-errors", false, "send the stack of the panics recovered in the handlers to the callers")
+
+	tlsCert     = flag.String("tls-cert", "", "PEM server certificate, enables TLS")
+	tlsKey      = flag.String("tls-key", "", "PEM key of the server certificate")
+	tlsClientCA = flag.String("tls-client-ca", "", "PEM CAs of the client certificates, enables mutual TLS")
+
+	apiKeysFile = flag.String("api-keys", "", "file of the accepted API keys, one \"<key> <subject> [<scopes>]\" line per key")
+	jwtKeyFile  = flag.String("jwt-key-file", "", "file holding the HS256 key of the accepted JWTs")
+	jwtIssuer   = flag.String("jwt-issuer", "", "issuer required in the accepted JWTs")
 )
 
 const (
-	port = ":50051"
+	defaultPageSize = 50
+	maxPageSize     = 1000
 )
 
+// updatableProductFields are the product fields UpdateProduct may overwrite.
+var updatableProductFields = []string{"name", "description", "price"}
+
 type server struct {
 	pb.UnimplementedProductInfoServiceServer
-	productMap map[string]*pb.Product
+	products store.ProductStore
+	// addProductRequests remembers the responses to AddProduct requests
+	// sent with an idempotency key
+	addProductRequests *idempotency.Cache
 }
 
 var _ pb.ProductInfoServiceServer = (*server)(nil)
 
 func (s *server) AddProduct(ctx context.Context,
 	in *pb.Product) (*pb.ProductID, error) {
+	key, err := idempotency.Key(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return idempotency.Do(ctx, s.addProductRequests, key, in, func() (*pb.ProductID, error) {
+		return s.addProduct(ctx, in)
+	})
+}
+
+func (s *server) addProduct(ctx context.Context, in *pb.Product) (*pb.ProductID, error) {
 	out, err := uuid.NewV4()
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Error while generating Product ID", err)
+		log.Printf("Failed to generate product id: %v", err)
+		return nil, rpcerr.New(codes.Internal, rpcerr.Internal, "failed to generate product id")
 	}
 	in.Id = out.String()
-	if s.productMap == nil {
-		s.productMap = make(map[string]*pb.Product)
+	if err := s.products.Add(ctx, in); err != nil {
+		return nil, storeError(err, in.Id)
 	}
-	s.productMap[in.Id] = in
 	log.Printf("Product %v : %v - Added.", in.Id, in.Name)
 	return &pb.ProductID{Value: in.Id}, status.New(codes.OK, "").Err()
 }
 
 func (s *server) GetProduct(ctx context.Context, in *pb.ProductID) (*pb.Product, error) {
-	product, exists := s.productMap[in.Value]
-	if exists && product != nil {
-		log.Printf("Product %v : %v - Retrieved.", product.Id, product.Name)
-		return product, status.New(codes.OK, "").Err()
+	product, err := s.products.Get(ctx, in.Value)
+	if err != nil {
+		return nil, storeError(err, in.Value)
 	}
-	return nil, status.Errorf(codes.NotFound, "Product does not exist.", in.Value)
+	log.Printf("Product %v : %v - Retrieved.", product.Id, product.Name)
+	return product, status.New(codes.OK, "").Err()
+}
+
+func (s *server) UpdateProduct(ctx context.Context, in *pb.UpdateProductRequest) (*pb.Product, error) {
+	update := in.GetProduct()
+	if update.GetId() == "" {
+		return nil, invalidArgumentError("product id is required", &epb.BadRequest_FieldViolation{
+			Field:       "product.id",
+			Description: "Product id can't be empty",
+		})
+	}
+
+	paths := in.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = updatableProductFields
+	}
+	var violations []*epb.BadRequest_FieldViolation
+	for _, path := range paths {
+		switch path {
+		case "name", "description", "price":
+		default:
+			violations = append(violations, &epb.BadRequest_FieldViolation{
+				Field:       "update_mask.paths",
+				Description: fmt.Sprintf("Field %q can't be updated", path),
+			})
+		}
+	}
+	if len(violations) > 0 {
+		log.Printf("Invalid UpdateProduct request for product %v", update.Id)
+		return nil, invalidArgumentError("invalid product update", violations...)
+	}
+
+	product, err := s.products.Update(ctx, update.Id, func(product *pb.Product) error {
+		for _, path := range paths {
+			switch path {
+			case "name":
+				product.Name = update.Name
+			case "description":
+				product.Description = update.Description
+			case "price":
+				product.Price = update.Price
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, storeError(err, update.Id)
+	}
+	log.Printf("Product %v : %v - Updated.", product.Id, product.Name)
+	return product, nil
+}
+
+func (s *server) DeleteProduct(ctx context.Context, in *pb.ProductID) (*emptypb.Empty, error) {
+	product, err := s.products.Delete(ctx, in.Value)
+	if err != nil {
+		return nil, storeError(err, in.Value)
+	}
+	log.Printf("Product %v : %v - Deleted.", product.Id, product.Name)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *server) ListProducts(ctx context.Context, in *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	var violations []*epb.BadRequest_FieldViolation
+	if in.MinPrice != nil && in.MaxPrice != nil && *in.MinPrice > *in.MaxPrice {
+		violations = append(violations, &epb.BadRequest_FieldViolation{
+			Field:       "max_price",
+			Description: fmt.Sprintf("Max price (%f) can't be less than min price (%f)", *in.MaxPrice, *in.MinPrice),
+		})
+	}
+	filter := listProductsFilter(in)
+	var after string
+	if in.PageToken != "" {
+		var err error
+		after, err = decodePageToken(in.PageToken, filter)
+		if err != nil {
+			violations = append(violations, &epb.BadRequest_FieldViolation{
+				Field:       "page_token",
+				Description: err.Error(),
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return nil, invalidArgumentError("invalid list products request", violations...)
+	}
+
+	pageSize := int(in.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+
+	products, err := s.products.List(ctx, after)
+	if err != nil {
+		return nil, storeError(err, "")
+	}
+
+	resp := &pb.ListProductsResponse{}
+	for _, product := range products {
+		if !matchesListFilter(product, in) {
+			continue
+		}
+		if len(resp.Products) == pageSize {
+			resp.NextPageToken = encodePageToken(resp.Products[pageSize-1].Id, filter)
+			break
+		}
+		resp.Products = append(resp.Products, product)
+	}
+	log.Printf("Listed %d products.", len(resp.Products))
+	return resp, nil
 }
 
 func main() {
-	lis, err := net.Listen("tcp", port)
+	flag.Parse()
+
+	exporter, err := tracing.NewExporter(*traceExporter, os.Stdout)
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	shutdownTracing := tracing.Setup("product-service", exporter)
+	defer shutdownTracing(context.Background())
+
+	authenticator, err := newAuthenticator()
+	if err != nil {
+		log.Fatalf("failed to set up authentication: %v", err)
+	}
+
+	creds, err := serverCredentials()
+	if err != nil {
+		log.Fatalf("failed to set up TLS: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", *port)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
-	pb.RegisterProductInfoServiceServer(s, &server{})
+	serverMetrics := interceptors.NewServerMetrics()
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		interceptors.UnaryServerTracing(interceptors.TracingOptions{}),
+		serverMetrics.UnaryServerInterceptor(),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		interceptors.StreamServerTracing(interceptors.TracingOptions{}),
+		serverMetrics.StreamServerInterceptor(),
+	}
+	if authenticator != nil {
+		authOpts := interceptors.AuthOptions{Authenticator: authenticator, Policy: productPolicy}
+		unaryInterceptors = append(unaryInterceptors, interceptors.UnaryServerAuth(authOpts))
+		streamInterceptors = append(streamInterceptors, interceptors.StreamServerAuth(authOpts))
+	}
+	unaryInterceptors = append(unaryInterceptors, interceptors.UnaryServerValidation(interceptors.ValidationOptions{}))
+	streamInterceptors = append(streamInterceptors, interceptors.StreamServerValidation(interceptors.ValidationOptions{}))
+	// Panics are recovered right around the handlers, so that the
+	// interceptors above count and trace them as Internal errors.
+	recovery := interceptors.NewRecovery(interceptors.RecoveryOptions{Debug: *debugErrors})
+	unaryInterceptors = append(unaryInterceptors, recovery.UnaryServerInterceptor())
+	streamInterceptors = append(streamInterceptors, recovery.StreamServerInterceptor())
+	s := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+	products := store.NewMemoryProductStore()
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	pb.RegisterProductInfoServiceServer(s, &server{
+		products:           products,
+		addProductRequests: idempotency.NewCache(*idempotencyTTL),
+	})
+	serverMetrics.InitializeMetrics(s)
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	reportHealth(healthCtx, healthServer, pb.ProductInfoService_ServiceDesc.ServiceName, products)
+
+	if *metricsAddress != "" {
+		if _, err := serveMetrics(*metricsAddress, serverMetrics, recovery); err != nil {
+			log.Fatalf("failed to serve metrics: %v", err)
+		}
+	}
+
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+		log.Print("Shutting down")
+		// Tell the load balancers to stop sending calls before draining.
+		stopHealth()
+		healthServer.Shutdown()
+		stopped := make(chan struct{})
+		go func() {
+			s.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(*shutdownTimeout):
+			// Health watches never end on their own.
+			log.Print("Cancelling the RPCs still in flight")
+			s.Stop()
+		}
+	}()
+
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"productinfo/service/pkg/idempotency"
+	"productinfo/service/pkg/store"
+	pb "productinfo/service/protos/product_info/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// newTestClient serves a ProductInfoService backed by an in-memory store
+// over an in-process connection.
+func newTestClient(t *testing.T) pb.ProductInfoServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	pb.RegisterProductInfoServiceServer(s, &server{
+		products:           store.NewMemoryProductStore(),
+		addProductRequests: idempotency.NewCache(time.Minute),
+	})
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewProductInfoServiceClient(conn)
+}
+
+// TestParallelClients runs clients adding, reading, updating and listing
+// products at the same time. Run it with -race.
+func TestParallelClients(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	const clients, productsPerClient = 8, 20
+	var wg sync.WaitGroup
+	for c := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range productsPerClient {
+				id, err := client.AddProduct(ctx, &pb.Product{Name: fmt.Sprintf("product %d-%d", c, i), Price: 1})
+				if err != nil {
+					t.Errorf("AddProduct: %v", err)
+					return
+				}
+				if _, err := client.UpdateProduct(ctx, &pb.UpdateProductRequest{
+					Product:    &pb.Product{Id: id.Value, Price: float32(i + 2)},
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price"}},
+				}); err != nil {
+					t.Errorf("UpdateProduct: %v", err)
+					return
+				}
+				product, err := client.GetProduct(ctx, id)
+				if err != nil {
+					t.Errorf("GetProduct: %v", err)
+					return
+				}
+				if product.Price != float32(i+2) {
+					t.Errorf("GetProduct(%s).Price = %v, want %v", id.Value, product.Price, i+2)
+				}
+				if _, err := client.ListProducts(ctx, &pb.ListProductsRequest{PageSize: 10}); err != nil {
+					t.Errorf("ListProducts: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	var listed int
+	req := &pb.ListProductsRequest{PageSize: 50}
+	for {
+		resp, err := client.ListProducts(ctx, req)
+		if err != nil {
+			t.Fatalf("ListProducts: %v", err)
+		}
+		listed += len(resp.Products)
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	if want := clients * productsPerClient; listed != want {
+		t.Errorf("listed %d products, want %d", listed, want)
+	}
+}
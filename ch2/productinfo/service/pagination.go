@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	pb "productinfo/service/protos/product_info/v1"
+)
+
+var errInvalidPageToken = errors.New("page token is invalid or doesn't match the request filters")
+
+// listProductsFilter returns a fingerprint of the ListProducts filters, so
+// that a page token can't be replayed against a different query.
+func listProductsFilter(in *pb.ListProductsRequest) uint32 {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%q", strings.ToLower(in.NameContains))
+	if in.MinPrice != nil {
+		fmt.Fprintf(h, "|min=%v", *in.MinPrice)
+	}
+	if in.MaxPrice != nil {
+		fmt.Fprintf(h, "|max=%v", *in.MaxPrice)
+	}
+	return h.Sum32()
+}
+
+func matchesListFilter(p *pb.Product, in *pb.ListProductsRequest) bool {
+	if in.NameContains != "" && !strings.Contains(strings.ToLower(p.Name), strings.ToLower(in.NameContains)) {
+		return false
+	}
+	if in.MinPrice != nil && p.Price < *in.MinPrice {
+		return false
+	}
+	if in.MaxPrice != nil && p.Price > *in.MaxPrice {
+		return false
+	}
+	return true
+}
+
+// encodePageToken produces an opaque token pointing past the product lastID.
+func encodePageToken(lastID string, filter uint32) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%08x:%s", filter, lastID)))
+}
+
+// decodePageToken returns the id of the last product of the previous page.
+func decodePageToken(token string, filter uint32) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", errInvalidPageToken
+	}
+	tokenFilter, lastID, ok := strings.Cut(string(raw), ":")
+	if !ok || lastID == "" || tokenFilter != fmt.Sprintf("%08x", filter) {
+		return "", errInvalidPageToken
+	}
+	return lastID, nil
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// APIKeys authenticates static API keys. Keys are kept hashed so that
+// looking them up doesn't depend on how much of a key matches.
+type APIKeys struct {
+	identities map[[sha256.Size]byte]*Identity
+}
+
+func NewAPIKeys() *APIKeys {
+	return &APIKeys{identities: make(map[[sha256.Size]byte]*Identity)}
+}
+
+// Add registers key as the credentials of id.
+func (k *APIKeys) Add(key string, id *Identity) {
+	k.identities[sha256.Sum256([]byte(key))] = id
+}
+
+func (k *APIKeys) Authenticate(_ context.Context, token string) (*Identity, error) {
+	id, ok := k.identities[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return id, nil
+}
+
+// LoadAPIKeys reads API keys from a file with one
+// "<key> <subject> [<scope>,<scope>...]" line per key. Empty lines and lines
+// starting with # are skipped.
+func LoadAPIKeys(path string) (*APIKeys, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keys := NewAPIKeys()
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, fmt.Errorf("%s:%d: expected <key> <subject> [<scopes>]", path, line)
+		}
+		id := &Identity{Subject: fields[1]}
+		if len(fields) == 3 {
+			id.Scopes = strings.Split(fields[2], ",")
+		}
+		keys.Add(fields[0], id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
@@ -0,0 +1,24 @@
+package rpcerr
+
+// Reason identifies the cause of an error in the ErrorInfo domain. Reasons
+// are UPPER_SNAKE_CASE and never change once published, so that callers can
+// rely on them.
+type Reason string
+
+// Reasons of the errors of the ecommerce services.
+const (
+	// InvalidRequest errors carry a BadRequest listing the invalid fields.
+	InvalidRequest Reason = "INVALID_REQUEST"
+	// Internal errors are failures of the server, not of the request.
+	Internal Reason = "INTERNAL"
+	// Unauthenticated calls lack a valid bearer token.
+	Unauthenticated Reason = "UNAUTHENTICATED"
+	// MissingScopes errors list the scopes the caller lacks in the
+	// "scopes" metadata.
+	MissingScopes Reason = "MISSING_SCOPES"
+	// IdempotencyKeyReused requests reused an idempotency key sent before
+	// with a different request.
+	IdempotencyKeyReused Reason = "IDEMPOTENCY_KEY_REUSED"
+
+	ProductNotFound Reason = "PRODUCT_NOT_FOUND"
+)
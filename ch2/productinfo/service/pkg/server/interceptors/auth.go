@@ -0,0 +1,88 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"productinfo/service/pkg/auth"
+	"productinfo/service/pkg/rpcerr"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// AuthOptions configures the authentication interceptors.
+type AuthOptions struct {
+	Authenticator auth.Authenticator
+	Policy        auth.Policy
+}
+
+// UnaryServerAuth authenticates the bearer token of unary RPCs and checks the
+// caller has the scopes required by the policy. The handlers find the caller
+// with auth.FromContext.
+func UnaryServerAuth(opts AuthOptions) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, opts, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerAuth authenticates the bearer token of streaming RPCs and
+// checks the caller has the scopes required by the policy.
+func StreamServerAuth(opts AuthOptions) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), opts, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authStream carries the identity of the caller in its context.
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+func authorize(ctx context.Context, opts AuthOptions, fullMethod string) (context.Context, error) {
+	if opts.Policy.IsPublic(fullMethod) {
+		return ctx, nil
+	}
+	token, err := auth.TokenFromContext(ctx)
+	if err != nil {
+		return nil, rpcerr.New(codes.Unauthenticated, rpcerr.Unauthenticated, err.Error())
+	}
+	id, err := opts.Authenticator.Authenticate(ctx, token)
+	if err != nil {
+		if !errors.Is(err, auth.ErrInvalidToken) {
+			slog.ErrorContext(ctx, "failed to authenticate token", slog.String("error", err.Error()))
+		}
+		return nil, rpcerr.New(codes.Unauthenticated, rpcerr.Unauthenticated, auth.ErrInvalidToken.Error())
+	}
+	if missing := opts.Policy.MissingScopes(fullMethod, id); len(missing) > 0 {
+		return nil, permissionDeniedError(id, missing)
+	}
+	return auth.NewContext(ctx, id), nil
+}
+
+// permissionDeniedError builds a PermissionDenied status carrying an
+// ErrorInfo that lists the missing scopes.
+func permissionDeniedError(id *auth.Identity, missing []string) error {
+	return rpcerr.Status{
+		Code:     codes.PermissionDenied,
+		Reason:   rpcerr.MissingScopes,
+		Message:  fmt.Sprintf("%s lacks the scopes %s", id.Subject, strings.Join(missing, ", ")),
+		Metadata: map[string]string{"scopes": strings.Join(missing, " ")},
+	}.Err()
+}
@@ -0,0 +1,145 @@
+package interceptors
+
+import (
+	"context"
+	"sync/atomic"
+
+	"productinfo/service/pkg/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const tracerName = "productinfo/service/pkg/server/interceptors"
+
+// TracingOptions configures the tracing interceptors.
+type TracingOptions struct {
+	// TracerProvider creates the spans. otel.GetTracerProvider() is used
+	// when nil.
+	TracerProvider trace.TracerProvider
+	// Propagator extracts the trace context from the request metadata.
+	// otel.GetTextMapPropagator() is used when nil.
+	Propagator propagation.TextMapPropagator
+}
+
+func (o TracingOptions) tracer() trace.Tracer {
+	tp := o.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(tracerName)
+}
+
+func (o TracingOptions) propagator() propagation.TextMapPropagator {
+	if o.Propagator == nil {
+		return otel.GetTextMapPropagator()
+	}
+	return o.Propagator
+}
+
+// UnaryServerTracing starts a server span for every unary RPC, continuing
+// the trace sent by the client in the traceparent metadata.
+func UnaryServerTracing(opts TracingOptions) grpc.UnaryServerInterceptor {
+	tracer, propagator := opts.tracer(), opts.propagator()
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, span := startSpan(ctx, tracer, propagator, info.FullMethod)
+		defer span.End()
+		addMessageEvent(span, semconv.RPCMessageTypeReceived, 1, req)
+		resp, err := handler(ctx, req)
+		if err == nil {
+			addMessageEvent(span, semconv.RPCMessageTypeSent, 1, resp)
+		}
+		endSpan(span, err)
+		return resp, err
+	}
+}
+
+// StreamServerTracing starts a server span for every streaming RPC, with an
+// event for every message received and sent.
+func StreamServerTracing(opts TracingOptions) grpc.StreamServerInterceptor {
+	tracer, propagator := opts.tracer(), opts.propagator()
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startSpan(ss.Context(), tracer, propagator, info.FullMethod)
+		defer span.End()
+		err := handler(srv, &tracingStream{ServerStream: ss, ctx: ctx, span: span})
+		endSpan(span, err)
+		return err
+	}
+}
+
+// tracingStream carries the RPC span in its context and records its
+// messages as span events.
+type tracingStream struct {
+	grpc.ServerStream
+	ctx            context.Context
+	span           trace.Span
+	received, sent atomic.Int64
+}
+
+func (s *tracingStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *tracingStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		addMessageEvent(s.span, semconv.RPCMessageTypeReceived, s.received.Add(1), m)
+	}
+	return err
+}
+
+func (s *tracingStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		addMessageEvent(s.span, semconv.RPCMessageTypeSent, s.sent.Add(1), m)
+	}
+	return err
+}
+
+func startSpan(ctx context.Context, tracer trace.Tracer, propagator propagation.TextMapPropagator, fullMethod string) (context.Context, trace.Span) {
+	ctx = tracing.Extract(ctx, propagator)
+	service, method := splitMethod(fullMethod)
+	attrs := []attribute.KeyValue{
+		semconv.RPCSystemGRPC,
+		semconv.RPCService(service),
+		semconv.RPCMethod(method),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		attrs = append(attrs, semconv.NetworkPeerAddress(p.Addr.String()))
+	}
+	return tracer.Start(ctx, fullMethod[1:],
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+func endSpan(span trace.Span, err error) {
+	st := status.Convert(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+	if err != nil {
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+}
+
+func addMessageEvent(span trace.Span, typ attribute.KeyValue, id int64, m any) {
+	span.AddEvent("message", trace.WithAttributes(
+		typ,
+		semconv.RPCMessageIDKey.Int64(id),
+		semconv.RPCMessageUncompressedSizeKey.Int(messageSize(m)),
+	))
+}
+
+func messageSize(m any) int {
+	if pm, ok := m.(proto.Message); ok {
+		return proto.Size(pm)
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	pb "productinfo/service/protos/product_info/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// MemoryProductStore is a ProductStore holding products in a map.
+type MemoryProductStore struct {
+	mu       sync.RWMutex
+	products map[string]*pb.Product
+}
+
+var _ ProductStore = (*MemoryProductStore)(nil)
+
+func NewMemoryProductStore() *MemoryProductStore {
+	return &MemoryProductStore{products: make(map[string]*pb.Product)}
+}
+
+func (s *MemoryProductStore) Add(_ context.Context, p *pb.Product) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.products[p.Id]; ok {
+		return ErrAlreadyExists
+	}
+	s.products[p.Id] = proto.Clone(p).(*pb.Product)
+	return nil
+}
+
+func (s *MemoryProductStore) Get(_ context.Context, id string) (*pb.Product, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.products[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return proto.Clone(p).(*pb.Product), nil
+}
+
+func (s *MemoryProductStore) Update(_ context.Context, id string, fn func(p *pb.Product) error) (*pb.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.products[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	updated := proto.Clone(p).(*pb.Product)
+	if err := fn(updated); err != nil {
+		return nil, err
+	}
+	updated.Id = id
+	s.products[id] = updated
+	return proto.Clone(updated).(*pb.Product), nil
+}
+
+func (s *MemoryProductStore) Delete(_ context.Context, id string) (*pb.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.products[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	delete(s.products, id)
+	return p, nil
+}
+
+func (s *MemoryProductStore) List(_ context.Context, after string) ([]*pb.Product, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	products := make([]*pb.Product, 0, len(s.products))
+	for id, p := range s.products {
+		if id > after {
+			products = append(products, proto.Clone(p).(*pb.Product))
+		}
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].Id < products[j].Id })
+	return products, nil
+}
@@ -0,0 +1,38 @@
+// Package store defines storage backends for the ProductInfoService.
+package store
+
+import (
+	"context"
+	"errors"
+
+	pb "productinfo/service/protos/product_info/v1"
+)
+
+var (
+	ErrNotFound      = errors.New("product not found")
+	ErrAlreadyExists = errors.New("product already exists")
+)
+
+// ProductStore persists products. Implementations must be safe for
+// concurrent use and must not retain or hand out messages that callers
+// may mutate afterwards.
+type ProductStore interface {
+	// Add stores a new product, keyed by its id.
+	Add(ctx context.Context, p *pb.Product) error
+	// Get returns the product with the given id or ErrNotFound.
+	Get(ctx context.Context, id string) (*pb.Product, error)
+	// Update atomically applies fn to the stored product and returns the
+	// updated copy. The product is left untouched if fn returns an error.
+	Update(ctx context.Context, id string, fn func(p *pb.Product) error) (*pb.Product, error)
+	// Delete removes the product with the given id and returns it.
+	Delete(ctx context.Context, id string) (*pb.Product, error)
+	// List returns the products with an id greater than after, ordered by id.
+	List(ctx context.Context, after string) ([]*pb.Product, error)
+}
+
+// HealthChecker is implemented by the stores that can become unhealthy,
+// e.g. when they lose their storage.
+type HealthChecker interface {
+	// Check returns an error when the store can't serve requests.
+	Check(ctx context.Context) error
+}
@@ -0,0 +1,96 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// checkInterval is how often the files are checked for changes, at most.
+const checkInterval = time.Second
+
+// reloader holds a value loaded from files and loads it again once the files
+// change. The change is noticed on the first access after checkInterval, so
+// no goroutine is needed. When loading the changed files fails, e.g. while a
+// certificate and its key are being replaced, the previous value is kept and
+// loading is retried on the next check.
+type reloader[T any] struct {
+	files []string
+	load  func() (T, error)
+
+	mu        sync.Mutex
+	value     T
+	modTimes  []time.Time
+	lastCheck time.Time
+}
+
+func newReloader[T any](load func() (T, error), files ...string) (*reloader[T], error) {
+	r := &reloader[T]{files: files, load: load}
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+	r.value, r.modTimes, r.lastCheck = value, r.stat(), time.Now()
+	return r, nil
+}
+
+func (r *reloader[T]) get() T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.lastCheck) < checkInterval {
+		return r.value
+	}
+	r.lastCheck = time.Now()
+	modTimes := r.stat()
+	if slices.Equal(modTimes, r.modTimes) {
+		return r.value
+	}
+	value, err := r.load()
+	if err != nil {
+		slog.Error("failed to reload TLS files, keeping the previous ones",
+			slog.Any("files", r.files), slog.String("error", err.Error()))
+		return r.value
+	}
+	slog.Info("reloaded TLS files", slog.Any("files", r.files))
+	r.value, r.modTimes = value, modTimes
+	return r.value
+}
+
+func (r *reloader[T]) stat() []time.Time {
+	modTimes := make([]time.Time, len(r.files))
+	for i, file := range r.files {
+		if fi, err := os.Stat(file); err == nil {
+			modTimes[i] = fi.ModTime()
+		}
+	}
+	return modTimes
+}
+
+func loadKeyPair(certFile, keyFile string) func() (*tls.Certificate, error) {
+	return func() (*tls.Certificate, error) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return &cert, nil
+	}
+}
+
+func loadCertPool(caFile string) func() (*x509.CertPool, error) {
+	return func() (*x509.CertPool, error) {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s: no PEM certificates", caFile)
+		}
+		return pool, nil
+	}
+}
@@ -0,0 +1,107 @@
+// Package tlsconfig builds the TLS configurations of the servers and
+// clients, reloading the certificates when their files change so that they
+// can be rotated without a restart.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+)
+
+// ServerOptions are the files of a server TLS configuration.
+type ServerOptions struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile enables mutual TLS: clients must present a certificate
+	// signed by one of its CAs.
+	ClientCAFile string
+}
+
+// Server returns the TLS configuration of a server. The certificate, the key
+// and the client CAs are reloaded when their files change.
+func Server(opts ServerOptions) (*tls.Config, error) {
+	if opts.CertFile == "" || opts.KeyFile == "" {
+		return nil, errors.New("server certificate and key are required")
+	}
+	cert, err := newReloader(loadKeyPair(opts.CertFile, opts.KeyFile), opts.CertFile, opts.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	var clientCAs *reloader[*x509.CertPool]
+	if opts.ClientCAFile != "" {
+		if clientCAs, err = newReloader(loadCertPool(opts.ClientCAFile), opts.ClientCAFile); err != nil {
+			return nil, err
+		}
+	}
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return cert.get(), nil
+		},
+	}
+	if clientCAs != nil {
+		// The client certificates are verified against the current CAs
+		// rather than a fixed ClientCAs pool, to pick up their reloads.
+		cfg.ClientAuth = tls.RequireAnyClientCert
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyClientCertificate(cs.PeerCertificates, clientCAs.get())
+		}
+	}
+	return cfg, nil
+}
+
+func verifyClientCertificate(certs []*x509.Certificate, roots *x509.CertPool) error {
+	if len(certs) == 0 {
+		return errors.New("missing client certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err
+}
+
+// ClientOptions are the files of a client TLS configuration.
+type ClientOptions struct {
+	// CAFile holds the CAs trusted to sign the server certificate. The
+	// system roots are used when empty.
+	CAFile string
+	// CertFile and KeyFile are the client certificate presented to servers
+	// requiring mutual TLS. They're reloaded when their files change.
+	CertFile string
+	KeyFile  string
+	// ServerName overrides the name checked in the server certificate,
+	// which defaults to the host of the dialed address.
+	ServerName string
+}
+
+// Client returns the TLS configuration of a client.
+func Client(opts ClientOptions) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: opts.ServerName,
+	}
+	if opts.CAFile != "" {
+		pool, err := loadCertPool(opts.CAFile)()
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		cert, err := newReloader(loadKeyPair(opts.CertFile, opts.KeyFile), opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return cert.get(), nil
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,96 @@
+// Package tracing sets up OpenTelemetry tracing and propagates the trace
+// context of RPCs through gRPC metadata.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"google.golang.org/grpc/metadata"
+)
+
+// Propagator propagates the W3C traceparent and tracestate headers along
+// with the W3C baggage.
+var Propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// NewExporter returns the span exporter with the given name:
+// "stdout" writes the spans to w as JSON and "none" drops them.
+func NewExporter(name string, w io.Writer) (sdktrace.SpanExporter, error) {
+	switch name {
+	case "stdout":
+		return stdouttrace.New(stdouttrace.WithWriter(w))
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", name)
+	}
+}
+
+// Setup installs a global tracer provider exporting the spans of the named
+// service with exporter, and the global Propagator. A nil exporter only
+// installs the propagator, so that trace contexts are still passed on.
+// The returned function flushes the pending spans and stops the provider.
+func Setup(serviceName string, exporter sdktrace.SpanExporter) func(context.Context) error {
+	otel.SetTextMapPropagator(Propagator)
+	if exporter == nil {
+		return func(context.Context) error { return nil }
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown
+}
+
+// MetadataCarrier adapts gRPC metadata to a propagation.TextMapCarrier.
+type MetadataCarrier metadata.MD
+
+func (c MetadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c MetadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Extract returns ctx with the trace context sent in the incoming metadata.
+func Extract(ctx context.Context, p propagation.TextMapPropagator) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return p.Extract(ctx, MetadataCarrier(md))
+}
+
+// Inject returns ctx with the trace context of ctx added to the outgoing
+// metadata.
+func Inject(ctx context.Context, p propagation.TextMapPropagator) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	p.Inject(ctx, MetadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
@@ -0,0 +1,122 @@
+// Package validate checks messages against the (ecommerce.v1.rules)
+// constraints declared on their fields in the .proto files.
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	pb "productinfo/service/protos/product_info/v1"
+
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Message returns the violations of the rules of the fields of m, including
+// the ones of nested messages, named by their path such as
+// "items[0].product_id". It returns nil when m is valid.
+func Message(m proto.Message) []*epb.BadRequest_FieldViolation {
+	if m == nil {
+		return nil
+	}
+	var violations []*epb.BadRequest_FieldViolation
+	validate(m.ProtoReflect(), "", &violations)
+	return violations
+}
+
+func validate(m protoreflect.Message, prefix string, violations *[]*epb.BadRequest_FieldViolation) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+		if rules := fieldRules(fd); rules != nil {
+			for _, description := range check(m, fd, rules) {
+				*violations = append(*violations, &epb.BadRequest_FieldViolation{Field: path, Description: description})
+			}
+		}
+		if fd.Message() == nil || fd.IsMap() || !m.Has(fd) {
+			continue
+		}
+		if fd.IsList() {
+			list := m.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				validate(list.Get(j).Message(), fmt.Sprintf("%s[%d].", path, j), violations)
+			}
+			continue
+		}
+		validate(m.Get(fd).Message(), path+".", violations)
+	}
+}
+
+// check returns the descriptions of the rules broken by the field fd of m.
+func check(m protoreflect.Message, fd protoreflect.FieldDescriptor, rules *pb.FieldRules) []string {
+	if !m.Has(fd) {
+		if rules.Required {
+			return []string{"Field is required"}
+		}
+		return nil
+	}
+	v := m.Get(fd)
+	if fd.IsMap() {
+		return nil
+	}
+	if !fd.IsList() {
+		return checkValue(fd, v, rules)
+	}
+	var broken []string
+	list := v.List()
+	if rules.MaxLen > 0 && list.Len() > int(rules.MaxLen) {
+		broken = append(broken, fmt.Sprintf("Field has %d elements - can't have more than %d", list.Len(), rules.MaxLen))
+	}
+	for i := 0; i < list.Len(); i++ {
+		for _, description := range checkValue(fd, list.Get(i), rules) {
+			broken = append(broken, fmt.Sprintf("Element %d: %s", i, description))
+		}
+	}
+	return broken
+}
+
+func checkValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, rules *pb.FieldRules) []string {
+	var broken []string
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		s := v.String()
+		if !fd.IsList() && rules.MaxLen > 0 && utf8.RuneCountInString(s) > int(rules.MaxLen) {
+			broken = append(broken, fmt.Sprintf("Value is %d characters long - can't be longer than %d", utf8.RuneCountInString(s), rules.MaxLen))
+		}
+		if rules.Uuid && !uuidPattern.MatchString(s) {
+			broken = append(broken, fmt.Sprintf("Value %q is not a valid UUID", s))
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		broken = checkRange(float64(v.Int()), rules)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		broken = checkRange(float64(v.Uint()), rules)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		broken = checkRange(v.Float(), rules)
+	}
+	return broken
+}
+
+func checkRange(f float64, rules *pb.FieldRules) []string {
+	if rules.Min != nil && f < *rules.Min {
+		return []string{fmt.Sprintf("Value %v is not valid - can't be less than %v", f, *rules.Min)}
+	}
+	if rules.Max != nil && f > *rules.Max {
+		return []string{fmt.Sprintf("Value %v is not valid - can't be more than %v", f, *rules.Max)}
+	}
+	return nil
+}
+
+func fieldRules(fd protoreflect.FieldDescriptor) *pb.FieldRules {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil || !proto.HasExtension(opts, pb.E_Rules) {
+		return nil
+	}
+	return proto.GetExtension(opts, pb.E_Rules).(*pb.FieldRules)
+}
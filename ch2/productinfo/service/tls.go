@@ -0,0 +1,31 @@
+package main
+
+import (
+	"log"
+
+	"productinfo/service/pkg/tlsconfig"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// serverCredentials returns the transport credentials selected with the
+// -tls-* flags: TLS when a certificate is set, mutual TLS when client CAs
+// are set too, and plaintext otherwise.
+func serverCredentials() (credentials.TransportCredentials, error) {
+	if *tlsCert == "" && *tlsKey == "" {
+		if *tlsClientCA != "" {
+			log.Print("Ignoring -tls-client-ca without -tls-cert and -tls-key")
+		}
+		return insecure.NewCredentials(), nil
+	}
+	cfg, err := tlsconfig.Server(tlsconfig.ServerOptions{
+		CertFile:     *tlsCert,
+		KeyFile:      *tlsKey,
+		ClientCAFile: *tlsClientCA,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(cfg), nil
+}
@@ -9,6 +9,7 @@ import (
 	"math/rand/v2"
 	"net"
 
+	"ch3/svc/pkg/store"
 	pb "ch3/svc/protos/ordermgt/v1"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
@@ -25,23 +26,26 @@ const (
 
 type server struct {
 	pb.UnimplementedOrderManagementServiceServer
+	orders store.OrderStore
 }
 
 var _ pb.OrderManagementServiceServer = (*server)(nil)
 
-type Order struct {
-	Id    string
-	Price float32
+// orderStoreError converts an OrderStore error into a gRPC status.
+func orderStoreError(err error, orderId string) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return status.New(codes.NotFound, fmt.Sprintf("order id=\"%s\" not found", orderId)).Err()
+	}
+	log.Printf("order store error: %v", err)
+	return status.New(codes.Internal, "order store failure").Err()
 }
 
-var (
-	orders = map[string]Order{}
-)
-
 func (s *server) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
 	log.Printf("Create order with price = %.2f", req.Price)
 	id := uuid.NewString()
-	orders[id] = Order{Id: id, Price: req.Price}
+	if err := s.orders.Create(ctx, store.Order{Id: id, Price: req.Price}); err != nil {
+		return nil, orderStoreError(err, id)
+	}
 	return &pb.CreateOrderResponse{Id: id, Price: req.Price}, nil
 }
 
@@ -63,23 +67,29 @@ func (s *server) CreateOrders(stream grpc.ClientStreamingServer[pb.CreateOrdersR
 		}
 
 		orderId := uuid.NewString()
-		orders[orderId] = Order{Id: orderId, Price: orderReq.Price}
+		if err := s.orders.Create(stream.Context(), store.Order{Id: orderId, Price: orderReq.Price}); err != nil {
+			return orderStoreError(err, orderId)
+		}
 		createdOrdersIds = append(createdOrdersIds, orderId)
 	}
 }
 
 func (s *server) GetOrder(ctx context.Context, orderId *wrappers.StringValue) (*pb.GetOrderResponse, error) {
-	log.Printf("Get order id = \"%s\"\n", orderId)
+	log.Printf("Get order id = \"%s\"\n", orderId.GetValue())
 
-	order, ok := orders[orderId.String()]
-	if !ok {
-		return nil, status.New(codes.NotFound, fmt.Sprintf("order id=\"%s\" not found", orderId.String())).Err()
+	order, err := s.orders.Get(ctx, orderId.GetValue())
+	if err != nil {
+		return nil, orderStoreError(err, orderId.GetValue())
 	}
 
 	return &pb.GetOrderResponse{Id: order.Id, Price: order.Price}, status.New(codes.OK, "").Err()
 }
 
 func (s *server) GetOrders(_ *emptypb.Empty, stream grpc.ServerStreamingServer[pb.GetOrdersResponse]) error {
+	orders, err := s.orders.List(stream.Context())
+	if err != nil {
+		return orderStoreError(err, "")
+	}
 	for _, order := range orders {
 		if err := stream.Send(&pb.GetOrdersResponse{Id: order.Id, Price: order.Price}); err != nil {
 			return err
@@ -102,9 +112,9 @@ func (s *server) PackOrders(stream grpc.BidiStreamingServer[pb.PackOrdersRequest
 		if err != nil {
 			return fmt.Errorf("failed to receive PackOrders request from stream: %v", err)
 		}
-		order, ok := orders[req.Id]
-		if !ok {
-			return status.New(codes.NotFound, fmt.Sprintf("Order id=\"%s\" not found", req.Id)).Err()
+		order, err := s.orders.Get(stream.Context(), req.Id)
+		if err != nil {
+			return orderStoreError(err, req.Id)
 		}
 		packedOrders = append(packedOrders, &pb.PackedOrder{Id: req.Id, Price: order.Price})
 		if len(packedOrders) == packSize {
@@ -123,7 +133,7 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 	s := grpc.NewServer()
-	pb.RegisterOrderManagementServiceServer(s, &server{})
+	pb.RegisterOrderManagementServiceServer(s, &server{orders: store.NewMemoryOrderStore()})
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryOrderStore is an OrderStore holding orders in a map.
+type MemoryOrderStore struct {
+	mu     sync.RWMutex
+	orders map[string]Order
+}
+
+var _ OrderStore = (*MemoryOrderStore)(nil)
+
+func NewMemoryOrderStore() *MemoryOrderStore {
+	return &MemoryOrderStore{orders: make(map[string]Order)}
+}
+
+func (s *MemoryOrderStore) Create(_ context.Context, order Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.orders[order.Id]; ok {
+		return ErrAlreadyExists
+	}
+	s.orders[order.Id] = order
+	return nil
+}
+
+func (s *MemoryOrderStore) Get(_ context.Context, id string) (Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	order, ok := s.orders[id]
+	if !ok {
+		return Order{}, ErrNotFound
+	}
+	return order, nil
+}
+
+func (s *MemoryOrderStore) List(_ context.Context) ([]Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	orders := make([]Order, 0, len(s.orders))
+	for _, order := range s.orders {
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
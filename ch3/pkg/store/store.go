@@ -0,0 +1,27 @@
+// Package store defines storage backends for the OrderManagementService.
+package store
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrNotFound      = errors.New("order not found")
+	ErrAlreadyExists = errors.New("order already exists")
+)
+
+type Order struct {
+	Id    string
+	Price float32
+}
+
+// OrderStore persists orders. Implementations must be safe for concurrent use.
+type OrderStore interface {
+	// Create stores a new order, keyed by its id.
+	Create(ctx context.Context, order Order) error
+	// Get returns the order with the given id or ErrNotFound.
+	Get(ctx context.Context, id string) (Order, error)
+	// List returns all the stored orders.
+	List(ctx context.Context) ([]Order, error)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"log"
+
+	"ch3/svc/pkg/auth"
+	pb "ch3/svc/protos/ordermgt/v1"
+)
+
+const (
+	scopeOrdersRead  = "orders:read"
+	scopeOrdersWrite = "orders:write"
+)
+
+// orderPolicy lists the scopes each OrderManagementService method requires.
+var orderPolicy = auth.Policy{
+	Scopes: map[string][]string{
+		pb.OrderManagementService_CreateOrder_FullMethodName:  {scopeOrdersWrite},
+		pb.OrderManagementService_CreateOrders_FullMethodName: {scopeOrdersWrite},
+		pb.OrderManagementService_PackOrders_FullMethodName:   {scopeOrdersWrite},
+		pb.OrderManagementService_ShipOrder_FullMethodName:    {scopeOrdersWrite},
+		pb.OrderManagementService_DeliverOrder_FullMethodName: {scopeOrdersWrite},
+		pb.OrderManagementService_CancelOrder_FullMethodName:  {scopeOrdersWrite},
+		pb.OrderManagementService_GetOrder_FullMethodName:     {scopeOrdersRead},
+		pb.OrderManagementService_GetOrders_FullMethodName:    {scopeOrdersRead},
+		pb.OrderManagementService_WatchOrders_FullMethodName:  {scopeOrdersRead},
+	},
+	Public: healthMethods,
+}
+
+// newAuthenticator builds the authenticator of the API keys and JWTs
+// enabled with the -api-keys and -jwt-key-file flags. It returns nil when
+// neither is set, leaving the server open to any caller.
+func newAuthenticator() (auth.Authenticator, error) {
+	var authenticators auth.Authenticators
+	if *apiKeysFile != "" {
+		keys, err := auth.LoadAPIKeys(*apiKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, keys)
+	}
+	if *jwtKeyFile != "" {
+		key, err := auth.LoadJWTKey(*jwtKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, &auth.JWTs{Key: key, Issuer: *jwtIssuer})
+	}
+	if len(authenticators) == 0 {
+		log.Print("Authentication is disabled, set -api-keys or -jwt-key-file to enable it")
+		return nil, nil
+	}
+	return authenticators, nil
+}
@@ -0,0 +1,167 @@
+// Command certgen generates a local CA and the server and client
+// certificates it signs, for running the servers with TLS or mutual TLS
+// without an external PKI. An existing CA in the output directory is reused,
+// so that certificates can be reissued to try out their hot reload.
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	outDir     = flag.String("out", "certs", "directory to write the certificates to")
+	hosts      = flag.String("hosts", "localhost,127.0.0.1,::1", "comma-separated host names and IPs of the server certificate")
+	clientName = flag.String("client-name", "client", "common name of the client certificate")
+	validity   = flag.Duration("validity", 30*24*time.Hour, "validity of the server and client certificates")
+)
+
+func main() {
+	flag.Parse()
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		log.Fatal(err)
+	}
+
+	ca, caKey, err := loadOrCreateCA(filepath.Join(*outDir, "ca.pem"), filepath.Join(*outDir, "ca-key.pem"))
+	if err != nil {
+		log.Fatalf("failed to set up CA: %v", err)
+	}
+
+	server := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "server"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range strings.Split(*hosts, ",") {
+		if ip := net.ParseIP(host); ip != nil {
+			server.IPAddresses = append(server.IPAddresses, ip)
+		} else if host != "" {
+			server.DNSNames = append(server.DNSNames, host)
+		}
+	}
+	if err := issue(server, ca, caKey, "server"); err != nil {
+		log.Fatalf("failed to issue server certificate: %v", err)
+	}
+
+	client := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: *clientName},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if err := issue(client, ca, caKey, "client"); err != nil {
+		log.Fatalf("failed to issue client certificate: %v", err)
+	}
+	log.Printf("Wrote ca.pem, server.pem, server-key.pem, client.pem and client-key.pem to %s", *outDir)
+}
+
+func loadOrCreateCA(certFile, keyFile string) (*x509.Certificate, crypto.Signer, error) {
+	certPEM, certErr := os.ReadFile(certFile)
+	keyPEM, keyErr := os.ReadFile(keyFile)
+	if certErr == nil && keyErr == nil {
+		return parseCA(certPEM, keyPEM)
+	}
+	if !errors.Is(certErr, os.ErrNotExist) || !errors.Is(keyErr, os.ErrNotExist) {
+		return nil, nil, fmt.Errorf("found only one of %s and %s", certFile, keyFile)
+	}
+
+	ca := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "grpc-up-running local CA"},
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	der, err := create(ca, ca, key.Public(), key, 10*365*24*time.Hour)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := writeFiles(certFile, keyFile, der, key); err != nil {
+		return nil, nil, err
+	}
+	ca, err = x509.ParseCertificate(der)
+	return ca, key, err
+}
+
+func parseCA(certPEM, keyPEM []byte) (*x509.Certificate, crypto.Signer, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, errors.New("invalid CA PEM files")
+	}
+	ca, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported CA key %T", key)
+	}
+	return ca, signer, nil
+}
+
+// issue writes a certificate for template, signed by the CA, to name.pem
+// and its key to name-key.pem.
+func issue(template, ca *x509.Certificate, caKey crypto.Signer, name string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	der, err := create(template, ca, key.Public(), caKey, *validity)
+	if err != nil {
+		return err
+	}
+	return writeFiles(filepath.Join(*outDir, name+".pem"), filepath.Join(*outDir, name+"-key.pem"), der, key)
+}
+
+func create(template, parent *x509.Certificate, pub crypto.PublicKey, signer crypto.Signer, validity time.Duration) ([]byte, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template.SerialNumber = serial
+	template.NotBefore = time.Now().Add(-time.Minute)
+	template.NotAfter = time.Now().Add(validity)
+	return x509.CreateCertificate(rand.Reader, template, parent, pub, signer)
+}
+
+// writeFiles writes the certificate and its key, replacing the files
+// atomically so that servers reloading them never read a partial file.
+func writeFiles(certFile, keyFile string, der []byte, key crypto.Signer) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		return err
+	}
+	return writeFileAtomic(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644)
+}
+
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
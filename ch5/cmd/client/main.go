@@ -3,22 +3,30 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"math/rand/v2"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"ch3/svc/pkg/client/credentials"
+	"ch3/svc/pkg/client/healthcheck"
 	"ch3/svc/pkg/client/interceptors"
+	"ch3/svc/pkg/rpcerr"
+	"ch3/svc/pkg/tlsconfig"
+	"ch3/svc/pkg/tracing"
 	pb "ch3/svc/protos/ordermgt/v1"
 
-	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	grpccredentials "google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -26,7 +34,28 @@ const (
 	address = "localhost:50051"
 )
 
+var (
+	traceExporter = flag.String("trace-exporter", "none", "exporter of the OpenTelemetry spans: none or stdout")
+	token         = flag.String("token", "", "bearer token, an API key or a JWT, sent with every RPC")
+	caFile        = flag.String("ca", "", "PEM CAs of the server certificate, enables TLS")
+	certFile      = flag.String("cert", "", "PEM client certificate for mutual TLS")
+	keyFile       = flag.String("key", "", "PEM key of the client certificate")
+)
+
+// breakers are shared by all the connections so that the calls made after
+// the server went down fail fast.
+var breakers = interceptors.NewCircuitBreakers(interceptors.BreakerOptions{})
+
 func main() {
+	flag.Parse()
+
+	exporter, err := tracing.NewExporter(*traceExporter, os.Stdout)
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	shutdownTracing := tracing.Setup("order-client", exporter)
+	defer shutdownTracing(context.Background())
+
 	// FillWithOrders(5)
 
 	// orders := receiveOrders()
@@ -39,12 +68,42 @@ func main() {
 }
 
 func NewClient() (*grpc.ClientConn, error) {
-	conn, err := grpc.NewClient(
-		address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithUnaryInterceptor(interceptors.OrderUnaryInterceptor),
-		grpc.WithStreamInterceptor(interceptors.OrderStreamInterceptor),
-	)
+	creds := insecure.NewCredentials()
+	if *caFile != "" {
+		cfg, err := tlsconfig.Client(tlsconfig.ClientOptions{CAFile: *caFile, CertFile: *certFile, KeyFile: *keyFile})
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up TLS: %w", err)
+		}
+		creds = grpccredentials.NewTLS(cfg)
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(healthcheck.ServiceConfig(pb.OrderManagementService_ServiceDesc.ServiceName)),
+		grpc.WithChainUnaryInterceptor(
+			interceptors.UnaryClientErrors(),
+			interceptors.UnaryClientTracing(interceptors.TracingOptions{}),
+			interceptors.UnaryClientRetry(interceptors.RetryOptions{
+				NonIdempotent: []string{
+					pb.OrderManagementService_CreateOrder_FullMethodName,
+					pb.OrderManagementService_ShipOrder_FullMethodName,
+					pb.OrderManagementService_DeliverOrder_FullMethodName,
+					pb.OrderManagementService_CancelOrder_FullMethodName,
+				},
+			}),
+			breakers.UnaryClientInterceptor(),
+			interceptors.UnaryClientLogging(interceptors.LoggingOptions{}),
+		),
+		grpc.WithChainStreamInterceptor(
+			interceptors.StreamClientErrors(),
+			interceptors.StreamClientTracing(interceptors.TracingOptions{}),
+			breakers.StreamClientInterceptor(),
+			interceptors.StreamClientLogging(interceptors.LoggingOptions{}),
+		),
+	}
+	if *token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(credentials.BearerToken{Token: *token, AllowInsecure: *caFile == ""}))
+	}
+	conn, err := grpc.NewClient(address, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize gRPC client: %w", err)
 	}
@@ -135,6 +194,26 @@ func printPackedOrders(done chan<- struct{}, clientStream grpc.BidiStreamingClie
 }
 
 func CreateOrder(price float32) *pb.CreateOrderResponse {
+	return createOrder(&pb.CreateOrderRequest{Price: price})
+}
+
+// CreateOrderIdempotent creates an order that is created only once no matter
+// how many times it's retried with the same key.
+func CreateOrderIdempotent(price float32, idempotencyKey string) *pb.CreateOrderResponse {
+	return createOrder(&pb.CreateOrderRequest{Price: price, IdempotencyKey: idempotencyKey})
+}
+
+// CreateOrderWithItems orders products by id, priced by the ProductInfoService.
+func CreateOrderWithItems(items ...*pb.LineItem) *pb.CreateOrderResponse {
+	return createOrder(&pb.CreateOrderRequest{Items: items})
+}
+
+func createOrder(req *pb.CreateOrderRequest) *pb.CreateOrderResponse {
+	if req.IdempotencyKey == "" {
+		// Lets the retry interceptor retry the call without risking
+		// creating the order twice.
+		req.IdempotencyKey = uuid.NewString()
+	}
 	conn, err := NewClient()
 	if err != nil {
 		log.Fatal(err)
@@ -146,22 +225,20 @@ func CreateOrder(price float32) *pb.CreateOrderResponse {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 
-	resp, err := c.CreateOrder(ctx, &pb.CreateOrderRequest{Price: price})
+	resp, err := c.CreateOrder(ctx, req)
 	if err != nil {
-		errorCode := status.Code(err)
-		if errorCode == codes.InvalidArgument {
-			errorStatus := status.Convert(err)
-			for _, d := range errorStatus.Details() {
-				switch info := d.(type) {
-				case *epb.BadRequest_FieldViolation:
-					log.Printf("Request field invalid: %s", info)
-				default:
-					log.Printf("Unexpected error type: %s", info)
-
-				}
+		switch reason := rpcerr.ReasonOf(err); reason {
+		case rpcerr.InvalidRequest:
+			for _, violation := range rpcerr.FieldViolations(err) {
+				log.Printf("Request field invalid: %s", violation)
 			}
-		} else if errorCode == codes.Unknown {
+		case rpcerr.RateLimited:
+			delay, _ := rpcerr.RetryDelay(err)
+			log.Printf("CreateOrder rejected, retry in %v: %v", delay, err)
+		case "":
 			log.Printf("Unhandled CreateOrder error: %v", err)
+		default:
+			log.Printf("CreateOrder failed (%s): %v", reason, err)
 		}
 		return nil
 	}
@@ -207,17 +284,23 @@ func addOrdersStreaming(orders []Order) {
 		log.Fatalf("Failed to open CreateOrders request stream: %v", err)
 	}
 
-	for _, order := range orders {
-		if err := stream.Send(&pb.CreateOrdersRequest{Price: order.Price}); err != nil {
+	for i, order := range orders {
+		req := &pb.CreateOrdersRequest{Price: order.Price, CorrelationId: strconv.Itoa(i)}
+		if err := stream.Send(req); err != nil {
 			log.Fatalf("Failed to create order: %v", err)
 		}
-		log.Printf("Placed with price=\"%.2f\"", order.Price)
+		log.Printf("Placed #%s with price=\"%.2f\"", req.CorrelationId, order.Price)
 	}
 	log.Printf("Placed all orders!")
 	resp, err := stream.CloseAndRecv()
 	if err != nil {
 		log.Fatalf("error placing orders: %v", err)
 	}
+	for _, result := range resp.Results {
+		if result.GetError() != nil {
+			log.Printf("Order #%s failed: %v", result.CorrelationId, status.FromProto(result.GetError()).Err())
+		}
+	}
 	log.Printf("Created orders: [%s]", strings.Join(resp.CreatedOrders, ", "))
 
 }
@@ -240,13 +323,13 @@ func GetOrder(orderId string) *pb.GetOrderResponse {
 	// }()
 	orderResp, err := c.GetOrder(ctx, wrapperspb.String(orderId))
 	if err != nil {
-		log.Fatal("Couldn't GetOrder: %v", err)
+		log.Fatalf("Couldn't GetOrder: %v", err)
 	}
 
 	return orderResp
 }
 
-func receiveOrders() []*pb.GetOrdersResponse {
+func ShipOrder(orderId string) *pb.ShipOrderResponse {
 	conn, err := NewClient()
 	if err != nil {
 		log.Fatal(err)
@@ -257,29 +340,148 @@ func receiveOrders() []*pb.GetOrdersResponse {
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	ordersReq, err := c.GetOrders(ctx, &emptypb.Empty{})
+	resp, err := c.ShipOrder(ctx, &pb.ShipOrderRequest{Id: orderId})
+	if err != nil {
+		logOrderStatusError(err)
+		return nil
+	}
+	return resp
+}
 
-	done := make(chan struct{})
+func DeliverOrder(orderId string) *pb.DeliverOrderResponse {
+	conn, err := NewClient()
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	var orders []*pb.GetOrdersResponse
+	defer conn.Close()
+	c := pb.NewOrderManagementServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err := c.DeliverOrder(ctx, &pb.DeliverOrderRequest{Id: orderId})
+	if err != nil {
+		logOrderStatusError(err)
+		return nil
+	}
+	return resp
+}
+
+func CancelOrder(orderId string) *pb.CancelOrderResponse {
+	conn, err := NewClient()
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	go func() {
-		for i := 0; ; i++ {
+	defer conn.Close()
+	c := pb.NewOrderManagementServiceClient(conn)
 
-			o, err := ordersReq.Recv()
-			if errors.Is(err, io.EOF) {
-				done <- struct{}{}
-				return
-			}
-			if err != nil {
-				log.Fatal("cannot receive order")
-			}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err := c.CancelOrder(ctx, &pb.CancelOrderRequest{Id: orderId})
+	if err != nil {
+		logOrderStatusError(err)
+		return nil
+	}
+	return resp
+}
+
+func logOrderStatusError(err error) {
+	if rpcerr.ReasonOf(err) != rpcerr.InvalidOrderStatus {
+		log.Printf("Couldn't change order status: %v", err)
+		return
+	}
+	for _, violation := range rpcerr.PreconditionViolations(err) {
+		log.Printf("Order status precondition failed: %s", violation)
+	}
+}
+
+// maxReceiveOrdersAttempts bounds how many times receiveOrders reconnects
+// after the orders stream was interrupted.
+const maxReceiveOrdersAttempts = 3
+
+func receiveOrders() []*pb.GetOrdersResponse {
+	conn, err := NewClient()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer conn.Close()
+	c := pb.NewOrderManagementServiceClient(conn)
+
+	var orders []*pb.GetOrdersResponse
+	req := &pb.GetOrdersRequest{}
+	for attempt := 1; ; attempt++ {
+		err := streamOrders(c, req, func(o *pb.GetOrdersResponse) {
 			orders = append(orders, o)
-			log.Printf("Order %d: id=\"%s\", price = %.2f", i+1, o.Id, o.Price)
+			// Resume right after the last received order on reconnect
+			req.ResumeCursor = o.Cursor
+			log.Printf("Order %d: id=\"%s\", price = %.2f, status = %s", len(orders), o.Id, o.Price, o.Status)
+		})
+		if err == nil {
+			break
 		}
-	}()
+		code := status.Code(err)
+		if attempt == maxReceiveOrdersAttempts || (code != codes.Unavailable && code != codes.DeadlineExceeded) {
+			log.Fatalf("cannot receive orders: %v", err)
+		}
+		log.Printf("Orders stream interrupted (%v), resuming after %d orders", err, len(orders))
+	}
 
-	<-done
 	fmt.Println("Received all orders")
 	return orders
 }
+
+// streamOrders calls onOrder for every order streamed by GetOrders.
+func streamOrders(c pb.OrderManagementServiceClient, req *pb.GetOrdersRequest, onOrder func(*pb.GetOrdersResponse)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	stream, err := c.GetOrders(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		o, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		onOrder(o)
+	}
+}
+
+// WatchOrders logs order changes until an unrecoverable error, reconnecting
+// from the last received revision when the stream is interrupted.
+func WatchOrders() {
+	conn, err := NewClient()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer conn.Close()
+	c := pb.NewOrderManagementServiceClient(conn)
+
+	var startRevision uint64
+	for {
+		stream, err := c.WatchOrders(context.Background(), &pb.WatchOrdersRequest{StartRevision: startRevision})
+		if err != nil {
+			log.Fatalf("cannot watch orders: %v", err)
+		}
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				code := status.Code(err)
+				if code != codes.Unavailable && code != codes.Aborted {
+					log.Fatalf("cannot watch orders: %v", err)
+				}
+				log.Printf("Orders watch interrupted (%v), resuming from revision %d", err, startRevision)
+				time.Sleep(time.Second)
+				break
+			}
+			startRevision = ev.Revision + 1
+			log.Printf("Order event %d: %s id=\"%s\", status = %s", ev.Revision, ev.Type, ev.Order.GetId(), ev.Order.GetStatus())
+		}
+	}
+}
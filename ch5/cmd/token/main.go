@@ -0,0 +1,44 @@
+// Command token issues JWTs accepted by the servers started with the same
+// -jwt-key-file.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"ch3/svc/pkg/auth"
+)
+
+var (
+	keyFile = flag.String("jwt-key-file", "", "file holding the HS256 signing key")
+	issuer  = flag.String("issuer", "", "issuer of the token")
+	subject = flag.String("subject", "", "caller the token is issued to")
+	scopes  = flag.String("scopes", "", "comma-separated scopes granted to the caller, e.g. orders:read,orders:write")
+	ttl     = flag.Duration("ttl", time.Hour, "validity of the token")
+)
+
+func main() {
+	flag.Parse()
+	if *keyFile == "" || *subject == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	key, err := auth.LoadJWTKey(*keyFile)
+	if err != nil {
+		log.Fatalf("failed to read signing key: %v", err)
+	}
+	id := &auth.Identity{Subject: *subject}
+	if *scopes != "" {
+		id.Scopes = strings.Split(*scopes, ",")
+	}
+	token, err := (&auth.JWTs{Key: key, Issuer: *issuer}).Sign(id, *ttl)
+	if err != nil {
+		log.Fatalf("failed to sign token: %v", err)
+	}
+	fmt.Println(token)
+}
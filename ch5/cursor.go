@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"ch3/svc/pkg/store"
+	pb "ch3/svc/protos/ordermgt/v1"
+
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+var errInvalidCursor = errors.New("cursor is invalid")
+
+// ordersFilter selects the orders streamed by GetOrders.
+type ordersFilter struct {
+	minPrice, maxPrice *float32
+	statuses           []pb.OrderStatus
+	createdAfter       time.Time
+	createdBefore      time.Time
+	// position of the last order the client received, if resuming
+	after *store.Order
+}
+
+func newOrdersFilter(req *pb.GetOrdersRequest) (*ordersFilter, error) {
+	f := &ordersFilter{
+		minPrice: req.MinPrice,
+		maxPrice: req.MaxPrice,
+		statuses: req.Statuses,
+	}
+	var violations []*epb.BadRequest_FieldViolation
+	if f.minPrice != nil && f.maxPrice != nil && *f.minPrice > *f.maxPrice {
+		violations = append(violations, &epb.BadRequest_FieldViolation{
+			Field:       "max_price",
+			Description: fmt.Sprintf("Max price (%f) can't be less than min price (%f)", *f.maxPrice, *f.minPrice),
+		})
+	}
+	if req.CreatedAfter != nil {
+		f.createdAfter = req.CreatedAfter.AsTime()
+	}
+	if req.CreatedBefore != nil {
+		f.createdBefore = req.CreatedBefore.AsTime()
+	}
+	if !f.createdAfter.IsZero() && !f.createdBefore.IsZero() && !f.createdAfter.Before(f.createdBefore) {
+		violations = append(violations, &epb.BadRequest_FieldViolation{
+			Field:       "created_before",
+			Description: "Created before must be later than created after",
+		})
+	}
+	if req.ResumeCursor != "" {
+		after, err := decodeOrderCursor(req.ResumeCursor)
+		if err != nil {
+			violations = append(violations, &epb.BadRequest_FieldViolation{
+				Field:       "resume_cursor",
+				Description: err.Error(),
+			})
+		}
+		f.after = after
+	}
+	if len(violations) > 0 {
+		return nil, invalidArgumentError("invalid get orders request", violations...)
+	}
+	return f, nil
+}
+
+func (f *ordersFilter) matches(order store.Order) bool {
+	if f.after != nil && order.Compare(*f.after) <= 0 {
+		return false
+	}
+	if f.minPrice != nil && order.Price < *f.minPrice {
+		return false
+	}
+	if f.maxPrice != nil && order.Price > *f.maxPrice {
+		return false
+	}
+	if len(f.statuses) > 0 && !slices.Contains(f.statuses, orderStatusToPb(order.Status)) {
+		return false
+	}
+	if !f.createdAfter.IsZero() && order.CreatedAt.Before(f.createdAfter) {
+		return false
+	}
+	if !f.createdBefore.IsZero() && !order.CreatedAt.Before(f.createdBefore) {
+		return false
+	}
+	return true
+}
+
+// encodeOrderCursor returns an opaque cursor to the position of order in
+// the GetOrders stream order.
+func encodeOrderCursor(order store.Order) string {
+	createdAt := order.CreatedAt
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d.%d:%s", createdAt.Unix(), createdAt.Nanosecond(), order.Id)))
+}
+
+// decodeOrderCursor returns the order position the cursor points to.
+func decodeOrderCursor(cursor string) (*store.Order, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	createdAt, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return nil, errInvalidCursor
+	}
+	secs, nanos, ok := strings.Cut(createdAt, ".")
+	if !ok {
+		return nil, errInvalidCursor
+	}
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	nsec, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	return &store.Order{Id: id, CreatedAt: time.Unix(sec, nsec).UTC()}, nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"ch3/svc/pkg/store"
+	pb "ch3/svc/protos/ordermgt/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOrderCursorRoundTrip(t *testing.T) {
+	order := store.Order{Id: "8df532fb-cdb8-4627-83c4-0ebab01586d9", CreatedAt: time.Unix(1700000000, 123456789).UTC()}
+	after, err := decodeOrderCursor(encodeOrderCursor(order))
+	if err != nil {
+		t.Fatalf("decodeOrderCursor: %v", err)
+	}
+	if after.Id != order.Id || !after.CreatedAt.Equal(order.CreatedAt) {
+		t.Errorf("decoded cursor = %+v, want %+v", after, order)
+	}
+	if order.Compare(*after) != 0 {
+		t.Error("decoded cursor doesn't point at the order")
+	}
+}
+
+func TestDecodeInvalidOrderCursor(t *testing.T) {
+	for _, cursor := range []string{"not base64!", "bm8tY29sb24", "MTIzOg", "YWJjOmlk", "MS5hOmlk"} {
+		if _, err := decodeOrderCursor(cursor); !errors.Is(err, errInvalidCursor) {
+			t.Errorf("decodeOrderCursor(%q) error = %v, want errInvalidCursor", cursor, err)
+		}
+	}
+}
+
+func TestNewOrdersFilterRejectsInvalidRequests(t *testing.T) {
+	for name, req := range map[string]*pb.GetOrdersRequest{
+		"price range": {MinPrice: proto.Float32(10), MaxPrice: proto.Float32(5)},
+		"cursor":      {ResumeCursor: "not base64!"},
+	} {
+		if _, err := newOrdersFilter(req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: newOrdersFilter error = %v, want InvalidArgument", name, err)
+		}
+	}
+}
+
+// receiveOrders returns the orders of a GetOrders stream, stopping after
+// limit orders when limit is positive.
+func receiveOrders(t *testing.T, client pb.OrderManagementServiceClient, req *pb.GetOrdersRequest, limit int) []*pb.GetOrdersResponse {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.GetOrders(ctx, req)
+	if err != nil {
+		t.Fatalf("GetOrders: %v", err)
+	}
+	var orders []*pb.GetOrdersResponse
+	for limit <= 0 || len(orders) < limit {
+		order, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		orders = append(orders, order)
+	}
+	return orders
+}
+
+func TestGetOrdersResumesFromCursor(t *testing.T) {
+	client := newTestClient(t, newTestServer())
+	ids := createTestOrders(t, client, 5)
+
+	all := receiveOrders(t, client, &pb.GetOrdersRequest{}, 0)
+	if len(all) != len(ids) {
+		t.Fatalf("got %d orders, want %d", len(all), len(ids))
+	}
+	// Orders are streamed oldest first, in the order they were created.
+	for i, order := range all {
+		if order.Id != ids[i] {
+			t.Fatalf("order %d is %s, want %s", i, order.Id, ids[i])
+		}
+	}
+
+	// The connection drops after two orders, the client resumes from the
+	// cursor of the last one it received.
+	first := receiveOrders(t, client, &pb.GetOrdersRequest{}, 2)
+	rest := receiveOrders(t, client, &pb.GetOrdersRequest{ResumeCursor: first[len(first)-1].Cursor}, 0)
+	resumed := append(first, rest...)
+	if len(resumed) != len(all) {
+		t.Fatalf("got %d orders across the resumed streams, want %d", len(resumed), len(all))
+	}
+	for i := range all {
+		if resumed[i].Id != all[i].Id {
+			t.Errorf("resumed order %d is %s, want %s", i, resumed[i].Id, all[i].Id)
+		}
+	}
+}
+
+func TestGetOrdersFilters(t *testing.T) {
+	client := newTestClient(t, newTestServer())
+	ids := createTestOrders(t, client, 5) // priced 1 to 5
+	if _, err := client.CancelOrder(context.Background(), &pb.CancelOrderRequest{Id: ids[3]}); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+
+	orders := receiveOrders(t, client, &pb.GetOrdersRequest{
+		MinPrice: proto.Float32(2),
+		MaxPrice: proto.Float32(5),
+		Statuses: []pb.OrderStatus{pb.OrderStatus_ORDER_STATUS_CREATED},
+	}, 0)
+	var got []string
+	for _, order := range orders {
+		got = append(got, order.Id)
+	}
+	want := []string{ids[1], ids[2], ids[4]}
+	if len(got) != len(want) {
+		t.Fatalf("got orders %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got orders %v, want %v", got, want)
+			break
+		}
+	}
+}
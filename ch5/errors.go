@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"ch3/svc/pkg/rpcerr"
+	"ch3/svc/pkg/store"
+
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const orderResourceType = "ecommerce.v1.Order"
+
+// invalidArgumentError builds an InvalidArgument status carrying
+// a BadRequest with the given field violations.
+func invalidArgumentError(msg string, violations ...*epb.BadRequest_FieldViolation) error {
+	return rpcerr.InvalidArgument(msg, violations...)
+}
+
+// orderStoreError converts an OrderStore error into a gRPC status.
+func orderStoreError(err error, orderId string) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return rpcerr.NotFound(rpcerr.OrderNotFound, orderResourceType, orderId)
+	}
+	var transitionErr *store.TransitionError
+	if errors.As(err, &transitionErr) {
+		return orderStatusError(transitionErr)
+	}
+	log.Printf("order store error: %v", err)
+	return rpcerr.New(codes.Internal, rpcerr.Internal, "order store failure")
+}
+
+// orderStatusError builds a FailedPrecondition status carrying
+// a PreconditionFailure that explains the rejected status transition.
+func orderStatusError(transitionErr *store.TransitionError) error {
+	return rpcerr.FailedPrecondition(rpcerr.InvalidOrderStatus, transitionErr.Error(), &epb.PreconditionFailure_Violation{
+		Type:        "ORDER_STATUS",
+		Subject:     "orders/" + transitionErr.OrderId,
+		Description: fmt.Sprintf("Order is %s, it can't become %s", transitionErr.From, transitionErr.To),
+	})
+}
+
+// productServiceError converts a failed ProductInfoService call into a status
+// for the order service callers, keeping codes they may want to retry on.
+func productServiceError(err error) error {
+	switch code := status.Code(err); code {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		return rpcerr.New(code, rpcerr.ProductServiceUnavailable, "product service is not available")
+	default:
+		return rpcerr.New(codes.Internal, rpcerr.ProductServiceFailure, "failed to get product")
+	}
+}
@@ -3,88 +3,204 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
-	"math/rand/v2"
+	"log/slog"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
+	clientcredentials "ch3/svc/pkg/client/credentials"
+	"ch3/svc/pkg/client/healthcheck"
+	clientinterceptors "ch3/svc/pkg/client/interceptors"
+	"ch3/svc/pkg/idempotency"
+	"ch3/svc/pkg/rpcerr"
+	"ch3/svc/pkg/server/interceptors"
+	"ch3/svc/pkg/store"
+	"ch3/svc/pkg/tracing"
+	"ch3/svc/pkg/validate"
+	"ch3/svc/pkg/watch"
 	pb "ch3/svc/protos/ordermgt/v1"
+	productpb "ch3/svc/protos/product_info/v1"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
-	"github.com/google/uuid"
-	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
 	port = ":50051"
 )
 
+var (
+	storeBackend  = flag.String("store", "memory", "order store backend: memory or file")
+	dataDir       = flag.String("data-dir", "data", "directory of the file order store")
+	snapshotEvery = flag.Int("snapshot-every", store.DefaultSnapshotEvery, "number of writes between file order store snapshots")
+
+	watchHistory = flag.Int("watch-history", 1000, "number of order events kept for WatchOrders watchers to catch up on")
+
+	idempotencyTTL = flag.Duration("idempotency-ttl", time.Hour, "how long CreateOrder responses are kept for requests with an idempotency key")
+
+	logFormat   = flag.String("log-format", "text", "log format: text or json")
+	logLevel    = flag.String("log-level", "info", "minimum log level: debug, info, warn or error")
+	logPayloads = flag.Bool("log-payloads", false, "log RPC messages, with their sensitive fields redacted")
+
+	debugErrors = flag.Bool("debug-errors", false, "send the stack of the panics recovered in the handlers to the callers")
+
+	rateLimit           = flag.Float64("rate-limit", 50, "calls per second allowed to each caller on each method, 0 for no limit")
+	rateBurst           = flag.Int("rate-burst", 100, "calls a caller can make at once on each method above -rate-limit")
+	maxStreams          = flag.Int("max-streams", 1000, "maximum number of streams in flight, 0 for no limit")
+	maxStreamsPerCaller = flag.Int("max-streams-per-caller", 20, "maximum number of streams in flight per caller, 0 for no limit")
+
+	traceExporter = flag.String("trace-exporter", "none", "exporter of the OpenTelemetry spans: none or stdout")
+
+	shutdownTimeout = flag.Duration("shutdown-timeout", 10*time.Second, "how long to wait for the RPCs in flight on shutdown before cancelling them")
+
+	metricsAddress = flag.String("metrics-address", "localhost:9090", "address of the Prometheus /metrics endpoint, empty to disable it")
+
+	tlsCert     = flag.String("tls-cert", "", "PEM server certificate, enables TLS")
+	tlsKey      = flag.String("tls-key", "", "PEM key of the server certificate")
+	tlsClientCA = flag.String("tls-client-ca", "", "PEM CAs of the client certificates, enables mutual TLS")
+
+	apiKeysFile = flag.String("api-keys", "", "file of the accepted API keys, one \"<key> <subject> [<scopes>]\" line per key")
+	jwtKeyFile  = flag.String("jwt-key-file", "", "file holding the HS256 key of the accepted JWTs")
+	jwtIssuer   = flag.String("jwt-issuer", "", "issuer required in the accepted JWTs")
+
+	productServiceAddress = flag.String("product-service-address", "localhost:50052", "address of the ProductInfoService resolving order items")
+	productServiceToken   = flag.String("product-service-token", "", "bearer token sent to the ProductInfoService")
+	productServiceCA      = flag.String("product-service-ca", "", "PEM CAs of the ProductInfoService certificate, enables TLS")
+	productServiceCert    = flag.String("product-service-cert", "", "PEM client certificate presented to the ProductInfoService")
+	productServiceKey     = flag.String("product-service-key", "", "PEM key of the ProductInfoService client certificate")
+)
+
 type server struct {
 	pb.UnimplementedOrderManagementServiceServer
+	orders   store.OrderStore
+	products productpb.ProductInfoServiceClient
+	events   *watch.Hub[orderEvent]
+	// createOrderRequests remembers the responses to CreateOrder requests
+	// sent with an idempotency key
+	createOrderRequests *idempotency.Cache
 }
 
+// watcherBufferSize is how many events a WatchOrders stream may lag behind
+// before it is dropped.
+const watcherBufferSize = 256
+
 var _ pb.OrderManagementServiceServer = (*server)(nil)
 
-type Order struct {
-	Id    string
-	Price float32
+func (s *server) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
+	key, err := idempotency.Key(ctx, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	return idempotency.Do(ctx, s.createOrderRequests, key, req, func() (*pb.CreateOrderResponse, error) {
+		return s.createOrder(ctx, req)
+	})
 }
 
-var (
-	orders = map[string]Order{}
-)
-
-func (s *server) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
-	if req.Price < 0 {
-		log.Printf("Invalid CreateOrder price requested: %.2f", req.Price)
-		errorStatus := status.New(codes.InvalidArgument, "")
-		ds, err := errorStatus.WithDetails(
-			&epb.BadRequest_FieldViolation{
-				Field:       "price",
-				Description: fmt.Sprintf("Price received (%f) is not valid - can't be negative", req.Price),
-			},
-		)
-		if err != nil {
-			// log the error generating details and return the base error
-			log.Printf("error generating validation details: %v", err)
-			return nil, errorStatus.Err()
-		}
-		// return the generated error
-		return nil, ds.Err()
+func (s *server) createOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
+	order, err := s.newOrder(ctx, req.Price, req.Items)
+	if err != nil {
+		log.Printf("Invalid CreateOrder request: %v", err)
+		return nil, err
 	}
 
-	log.Printf("Create order with price = %.2f", req.Price)
-	id := uuid.NewString()
-	orders[id] = Order{Id: id, Price: req.Price}
-	return &pb.CreateOrderResponse{Id: id, Price: req.Price}, nil
+	log.Printf("Create order with price = %.2f", order.Price)
+	if err := s.orders.Create(ctx, order); err != nil {
+		return nil, orderStoreError(err, order.Id)
+	}
+	return &pb.CreateOrderResponse{
+		Id:     order.Id,
+		Price:  order.Price,
+		Items:  orderItemsToPb(order.Items),
+		Status: orderStatusToPb(order.Status),
+	}, nil
 }
 
 func (s *server) CreateOrders(stream grpc.ClientStreamingServer[pb.CreateOrdersRequest, pb.CreateOrdersResponse]) error {
-	log.Print("Create orders")
-	var createdOrdersIds []string
+	ctx := stream.Context()
+	allOrNothing, err := allOrNothingFromMetadata(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("Create orders (all-or-nothing = %t)", allOrNothing)
+
+	var (
+		results []*pb.CreateOrderResult
+		// valid orders of an all-or-nothing stream, created once it ends
+		pending []store.Order
+		failed  bool
+	)
 	for {
 		orderReq, err := stream.Recv()
 		if errors.Is(err, io.EOF) {
-			log.Printf("Created %d orders", len(createdOrdersIds))
-			err := stream.SendAndClose(&pb.CreateOrdersResponse{CreatedOrders: createdOrdersIds})
-			if err != nil {
-				return fmt.Errorf("failed to close CreateOrders stream: %v", err)
+			break
+		}
+		if err != nil {
+			return rpcerr.Wrap(err, "failed to receive CreateOrders request")
+		}
+
+		result := &pb.CreateOrderResult{CorrelationId: orderReq.CorrelationId}
+		results = append(results, result)
+		// Invalid orders are reported in their result rather than failing
+		// the stream, so this method is exempt from the validation
+		// interceptor.
+		var order store.Order
+		if violations := validate.Message(orderReq); len(violations) > 0 {
+			err = invalidArgumentError("invalid order", violations...)
+		} else {
+			order, err = s.newOrder(ctx, orderReq.Price, orderReq.Items)
+		}
+		if err == nil && !allOrNothing {
+			if err = s.orders.Create(ctx, order); err != nil {
+				err = orderStoreError(err, order.Id)
 			}
-			return nil
 		}
 		if err != nil {
-			return fmt.Errorf("failed to receive CreateOrders request: %v", err)
+			log.Printf("Failed to create order %q: %v", orderReq.CorrelationId, err)
+			result.Result = &pb.CreateOrderResult_Error{Error: status.Convert(err).Proto()}
+			failed = true
+			continue
 		}
+		result.Result = &pb.CreateOrderResult_OrderId{OrderId: order.Id}
+		pending = append(pending, order)
+	}
 
-		orderId := uuid.NewString()
-		orders[orderId] = Order{Id: orderId, Price: orderReq.Price}
-		createdOrdersIds = append(createdOrdersIds, orderId)
+	if allOrNothing {
+		if failed {
+			aborted := status.Convert(rpcerr.New(codes.Aborted, rpcerr.OrdersNotCreated, "order not created - other orders of the all-or-nothing stream failed")).Proto()
+			for _, result := range results {
+				if result.GetOrderId() != "" {
+					result.Result = &pb.CreateOrderResult_Error{Error: aborted}
+				}
+			}
+		} else if err := s.orders.CreateMany(ctx, pending); err != nil {
+			return orderStoreError(err, "")
+		}
 	}
+
+	resp := &pb.CreateOrdersResponse{Results: results}
+	for _, result := range results {
+		if orderId := result.GetOrderId(); orderId != "" {
+			resp.CreatedOrders = append(resp.CreatedOrders, orderId)
+		}
+	}
+	log.Printf("Created %d of %d orders", len(resp.CreatedOrders), len(results))
+	if err := stream.SendAndClose(resp); err != nil {
+		return rpcerr.Wrap(err, "failed to close CreateOrders stream")
+	}
+	return nil
 }
 
 func (s *server) GetOrder(ctx context.Context, pbOrderId *wrappers.StringValue) (*pb.GetOrderResponse, error) {
@@ -104,67 +220,398 @@ func (s *server) GetOrder(ctx context.Context, pbOrderId *wrappers.StringValue)
 	// log.Printf("Context deadline exceeded: %t", errors.Is(ctx.Err(), context.DeadlineExceeded))
 	// log.Printf("Client RPC cancelled: %t", errors.Is(ctx.Err(), context.Canceled))
 
-	order, ok := orders[orderId]
-	if !ok {
-		return nil, status.New(codes.NotFound, fmt.Sprintf("order id=\"%s\" not found", orderId)).Err()
+	order, err := s.orders.Get(ctx, orderId)
+	if err != nil {
+		return nil, orderStoreError(err, orderId)
 	}
 
-	return &pb.GetOrderResponse{Id: order.Id, Price: order.Price}, status.New(codes.OK, "").Err()
+	return &pb.GetOrderResponse{
+		Id:         order.Id,
+		Price:      order.Price,
+		Items:      orderItemsToPb(order.Items),
+		Status:     orderStatusToPb(order.Status),
+		CreateTime: timestamppb.New(order.CreatedAt),
+	}, status.New(codes.OK, "").Err()
 }
 
-func (s *server) GetOrders(_ *emptypb.Empty, stream grpc.ServerStreamingServer[pb.GetOrdersResponse]) error {
+func (s *server) GetOrders(req *pb.GetOrdersRequest, stream grpc.ServerStreamingServer[pb.GetOrdersResponse]) error {
+	filter, err := newOrdersFilter(req)
+	if err != nil {
+		return err
+	}
+	orders, err := s.orders.List(stream.Context())
+	if err != nil {
+		return orderStoreError(err, "")
+	}
 	for _, order := range orders {
-		if err := stream.Send(&pb.GetOrdersResponse{Id: order.Id, Price: order.Price}); err != nil {
+		if !filter.matches(order) {
+			continue
+		}
+		resp := &pb.GetOrdersResponse{
+			Id:         order.Id,
+			Price:      order.Price,
+			Items:      orderItemsToPb(order.Items),
+			Status:     orderStatusToPb(order.Status),
+			CreateTime: timestamppb.New(order.CreatedAt),
+			Cursor:     encodeOrderCursor(order),
+		}
+		if err := stream.Send(resp); err != nil {
 			return err
 		}
 	}
 	return status.New(codes.OK, "").Err()
 }
 
-func (s *server) PackOrders(stream grpc.BidiStreamingServer[pb.PackOrdersRequest, pb.PackOrdersResponse]) error {
-	var packedOrders []*pb.PackedOrder
-	packSize := rand.IntN(3) + 2
-	for {
-		req, err := stream.Recv()
-		if errors.Is(err, io.EOF) {
-			if len(packedOrders) == 0 {
-				return nil
+func (s *server) PackOrders(stream grpc.BidiStreamingServer[pb.PackOrdersRequest, pb.PackOrdersResponse]) (err error) {
+	policy, err := packingPolicyFromMetadata(stream.Context())
+	if err != nil {
+		return err
+	}
+	p, flushAfter, err := newPacker(policy)
+	if err != nil {
+		return err
+	}
+
+	// Requests are received in a separate goroutine, so that the pending
+	// pack can be flushed while waiting for the next order.
+	reqs := make(chan *pb.PackOrdersRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
 			}
-			if err := stream.Send(&pb.PackOrdersResponse{Orders: packedOrders}); err != nil {
-				return fmt.Errorf("failed to send PackOrders response: %v", err)
+			select {
+			case reqs <- req:
+			case <-stream.Context().Done():
+				return
 			}
+		}
+	}()
+
+	flushTimer := time.NewTimer(time.Hour)
+	flushTimer.Stop()
+	defer flushTimer.Stop()
+
+	// Orders are marked PACKED as they are received, so that no other call
+	// can pack or cancel them meanwhile. The ones whose pack is never sent
+	// because the stream fails are moved back to CREATED.
+	var unsent []string
+	defer func() {
+		if err != nil && len(unsent) > 0 {
+			s.unpackOrders(context.WithoutCancel(stream.Context()), unsent)
+		}
+	}()
+
+	send := func(orders []*pb.PackedOrder) error {
+		if len(orders) == 0 {
 			return nil
 		}
-		if err != nil {
-			return fmt.Errorf("failed to receive PackOrders request from stream: %v", err)
+		if err := stream.Send(&pb.PackOrdersResponse{Orders: orders}); err != nil {
+			return rpcerr.Wrap(err, "failed to send PackOrders response")
 		}
-		order, ok := orders[req.Id]
-		if !ok {
-			return status.New(codes.NotFound, fmt.Sprintf("Order id=\"%s\" not found", req.Id)).Err()
+		// Packs hold the oldest unsent orders.
+		unsent = unsent[len(orders):]
+		return nil
+	}
+
+	for first := true; ; first = false {
+		select {
+		case req := <-reqs:
+			if first && req.Policy != nil {
+				if p, flushAfter, err = newPacker(req.Policy); err != nil {
+					return err
+				}
+				if req.Id == "" {
+					continue
+				}
+			}
+			order, err := s.orders.Update(stream.Context(), req.Id, store.Transition(store.StatusPacked))
+			if err != nil {
+				return orderStoreError(err, req.Id)
+			}
+			unsent = append(unsent, req.Id)
+			for _, pack := range p.Add(&pb.PackedOrder{Id: req.Id, Price: order.Price}) {
+				if err := send(pack); err != nil {
+					return err
+				}
+			}
+			if flushAfter > 0 {
+				if !flushTimer.Stop() {
+					select {
+					case <-flushTimer.C:
+					default:
+					}
+				}
+				flushTimer.Reset(flushAfter)
+			}
+		case <-flushTimer.C:
+			if err := send(p.Flush()); err != nil {
+				return err
+			}
+		case err := <-recvErr:
+			if errors.Is(err, io.EOF) {
+				return send(p.Flush())
+			}
+			return rpcerr.Wrap(err, "failed to receive PackOrders request from stream")
 		}
-		packedOrders = append(packedOrders, &pb.PackedOrder{Id: req.Id, Price: order.Price})
-		if len(packedOrders) == packSize {
-			if err := stream.Send(&pb.PackOrdersResponse{Orders: packedOrders}); err != nil {
-				return fmt.Errorf("failed to send PackOrders response: %v", err)
+	}
+}
+
+func (s *server) ShipOrder(ctx context.Context, req *pb.ShipOrderRequest) (*pb.ShipOrderResponse, error) {
+	order, err := s.transitionOrder(ctx, req.Id, store.StatusShipped)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ShipOrderResponse{Id: order.Id, Status: orderStatusToPb(order.Status)}, nil
+}
+
+func (s *server) DeliverOrder(ctx context.Context, req *pb.DeliverOrderRequest) (*pb.DeliverOrderResponse, error) {
+	order, err := s.transitionOrder(ctx, req.Id, store.StatusDelivered)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeliverOrderResponse{Id: order.Id, Status: orderStatusToPb(order.Status)}, nil
+}
+
+func (s *server) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
+	order, err := s.transitionOrder(ctx, req.Id, store.StatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CancelOrderResponse{Id: order.Id, Status: orderStatusToPb(order.Status)}, nil
+}
+
+func (s *server) WatchOrders(req *pb.WatchOrdersRequest, stream grpc.ServerStreamingServer[pb.OrderEvent]) error {
+	w, err := s.events.Watch(req.StartRevision)
+	if err != nil {
+		return rpcerr.Status{
+			Code:     codes.OutOfRange,
+			Reason:   rpcerr.RevisionOutOfRange,
+			Message:  fmt.Sprintf("can't watch from revision %d (current revision is %d): %v", req.StartRevision, s.events.Revision(), err),
+			Metadata: map[string]string{"current_revision": strconv.FormatUint(s.events.Revision(), 10)},
+		}.Err()
+	}
+	defer s.events.Unwatch(w)
+	log.Printf("Watch orders from revision %d", req.StartRevision)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case ev, ok := <-w.Events():
+			if !ok {
+				return rpcerr.Newf(codes.Aborted, rpcerr.WatcherDropped, "order watcher dropped: %v", w.Err())
+			}
+			if err := stream.Send(orderEventToPb(ev)); err != nil {
+				return err
 			}
-			packSize = rand.IntN(3) + 2
-			packedOrders = nil
 		}
 	}
 }
 
+func (s *server) transitionOrder(ctx context.Context, orderId string, to store.OrderStatus) (store.Order, error) {
+	order, err := s.orders.Update(ctx, orderId, store.Transition(to))
+	if err != nil {
+		return store.Order{}, orderStoreError(err, orderId)
+	}
+	log.Printf("Order id = \"%s\" is %s", order.Id, order.Status)
+	return order, nil
+}
+
+// newLogger builds the logger selected with the -log-format and -log-level flags.
+func newLogger() (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(*logLevel)); err != nil {
+		return nil, err
+	}
+	opts := &slog.HandlerOptions{Level: level}
+	switch *logFormat {
+	case "text":
+		return slog.New(slog.NewTextHandler(os.Stderr, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", *logFormat)
+	}
+}
+
+// newOrderStore opens the order store selected with the -store flag.
+// The returned func releases the store on shutdown.
+func newOrderStore() (store.OrderStore, func() error, error) {
+	switch *storeBackend {
+	case "memory":
+		return store.NewMemoryOrderStore(), func() error { return nil }, nil
+	case "file":
+		orders, err := store.OpenFileOrderStore(*dataDir, *snapshotEvery)
+		if err != nil {
+			return nil, nil, err
+		}
+		log.Printf("Opened file order store in %s", *dataDir)
+		return orders, orders.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown order store backend %q", *storeBackend)
+	}
+}
+
 func main() {
+	flag.Parse()
+
+	logger, err := newLogger()
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	slog.SetDefault(logger)
+
+	exporter, err := tracing.NewExporter(*traceExporter, os.Stdout)
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	shutdownTracing := tracing.Setup("order-service", exporter)
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Printf("failed to flush spans: %v", err)
+		}
+	}()
+
+	orders, closeOrders, err := newOrderStore()
+	if err != nil {
+		log.Fatalf("failed to open order store: %v", err)
+	}
+
+	authenticator, err := newAuthenticator()
+	if err != nil {
+		log.Fatalf("failed to set up authentication: %v", err)
+	}
+
+	serverCreds, err := serverCredentials()
+	if err != nil {
+		log.Fatalf("failed to set up TLS: %v", err)
+	}
+	productCreds, err := productServiceCredentials()
+	if err != nil {
+		log.Fatalf("failed to set up product service TLS: %v", err)
+	}
+
+	productMetrics := clientinterceptors.NewClientMetrics()
+	productBreakers := clientinterceptors.NewCircuitBreakers(clientinterceptors.BreakerOptions{})
+	productDialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(productCreds),
+		grpc.WithDefaultServiceConfig(healthcheck.ServiceConfig(productpb.ProductInfoService_ServiceDesc.ServiceName)),
+		grpc.WithChainUnaryInterceptor(
+			clientinterceptors.UnaryClientTracing(clientinterceptors.TracingOptions{}),
+			clientinterceptors.UnaryClientRetry(clientinterceptors.RetryOptions{}),
+			productBreakers.UnaryClientInterceptor(),
+			productMetrics.UnaryClientInterceptor(),
+			clientinterceptors.UnaryClientLogging(clientinterceptors.LoggingOptions{Payloads: *logPayloads}),
+		),
+	}
+	if *productServiceToken != "" {
+		productDialOpts = append(productDialOpts, grpc.WithPerRPCCredentials(clientcredentials.BearerToken{
+			Token:         *productServiceToken,
+			AllowInsecure: *productServiceCA == "",
+		}))
+	}
+	productConn, err := grpc.NewClient(*productServiceAddress, productDialOpts...)
+	if err != nil {
+		log.Fatalf("failed to initialize product service client: %v", err)
+	}
+	defer productConn.Close()
+
 	lis, err := net.Listen("tcp", port)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 	// Register the Interceptor at the server-side
+	serverMetrics := interceptors.NewServerMetrics()
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		interceptors.UnaryServerTracing(interceptors.TracingOptions{}),
+		serverMetrics.UnaryServerInterceptor(),
+		interceptors.UnaryServerLogging(interceptors.LoggingOptions{Payloads: *logPayloads}),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		interceptors.StreamServerTracing(interceptors.TracingOptions{}),
+		serverMetrics.StreamServerInterceptor(),
+		interceptors.StreamServerLogging(interceptors.LoggingOptions{Payloads: *logPayloads}),
+	}
+	if authenticator != nil {
+		authOpts := interceptors.AuthOptions{Authenticator: authenticator, Policy: orderPolicy}
+		unaryInterceptors = append(unaryInterceptors, interceptors.UnaryServerAuth(authOpts))
+		streamInterceptors = append(streamInterceptors, interceptors.StreamServerAuth(authOpts))
+	}
+	// Rate limits go after authentication to limit each caller separately.
+	rateLimiter := interceptors.NewRateLimiter(orderRateLimits())
+	unaryInterceptors = append(unaryInterceptors, rateLimiter.UnaryServerInterceptor())
+	streamInterceptors = append(streamInterceptors, rateLimiter.StreamServerInterceptor())
+	validationOpts := interceptors.ValidationOptions{
+		Exempt: []string{pb.OrderManagementService_CreateOrders_FullMethodName},
+	}
+	unaryInterceptors = append(unaryInterceptors, interceptors.UnaryServerValidation(validationOpts))
+	streamInterceptors = append(streamInterceptors, interceptors.StreamServerValidation(validationOpts))
+	// Panics are recovered last, right around the handlers, so that the
+	// interceptors above log, count and trace them as Internal errors.
+	recovery := interceptors.NewRecovery(interceptors.RecoveryOptions{Debug: *debugErrors})
+	unaryInterceptors = append(unaryInterceptors, recovery.UnaryServerInterceptor())
+	streamInterceptors = append(streamInterceptors, recovery.StreamServerInterceptor())
 	s := grpc.NewServer(
-		grpc.UnaryInterceptor(orderUnaryServerInterceptor),
-		grpc.StreamInterceptor(orderStreamServerInterceptor),
+		grpc.Creds(serverCreds),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 	)
-	pb.RegisterOrderManagementServiceServer(s, &server{})
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	events := watch.NewHub[orderEvent](max(*watchHistory, 0), watcherBufferSize)
+	orders.OnChange(publishOrderEvents(events))
+	pb.RegisterOrderManagementServiceServer(s, &server{
+		orders:   orders,
+		products: productpb.NewProductInfoServiceClient(productConn),
+		events:   events,
+
+		createOrderRequests: idempotency.NewCache(*idempotencyTTL),
+	})
+	serverMetrics.InitializeMetrics(s)
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	reportHealth(healthCtx, healthServer, pb.OrderManagementService_ServiceDesc.ServiceName, orders)
+
+	var metricsServer *http.Server
+	if *metricsAddress != "" {
+		metricsServer, err = serveMetrics(*metricsAddress, serverMetrics, recovery, productMetrics, productBreakers)
+		if err != nil {
+			log.Fatalf("failed to serve metrics: %v", err)
+		}
+	}
+
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+		log.Print("Shutting down")
+		// Tell the load balancers to stop sending calls before draining.
+		stopHealth()
+		healthServer.Shutdown()
+		stopped := make(chan struct{})
+		go func() {
+			s.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(*shutdownTimeout):
+			// Streams such as WatchOrders never end on their own.
+			log.Print("Cancelling the RPCs still in flight")
+			s.Stop()
+		}
+		if metricsServer != nil {
+			metricsServer.Close()
+		}
+	}()
+
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
+	if err := closeOrders(); err != nil {
+		log.Printf("failed to close order store: %v", err)
+	}
 }
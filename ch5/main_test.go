@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"ch3/svc/pkg/idempotency"
+	"ch3/svc/pkg/server/interceptors"
+	"ch3/svc/pkg/store"
+	"ch3/svc/pkg/watch"
+	pb "ch3/svc/protos/ordermgt/v1"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestServer returns an order server backed by an in-memory store. It
+// doesn't resolve line items, having no ProductInfoService.
+func newTestServer() *server {
+	orders := store.NewMemoryOrderStore()
+	events := watch.NewHub[orderEvent](100, watcherBufferSize)
+	orders.OnChange(publishOrderEvents(events))
+	return &server{
+		orders:              orders,
+		events:              events,
+		createOrderRequests: idempotency.NewCache(time.Minute),
+	}
+}
+
+// newTestClient serves srv over an in-process connection, validating the
+// requests like the server does.
+func newTestClient(t *testing.T, srv *server) pb.OrderManagementServiceClient {
+	t.Helper()
+	validationOpts := interceptors.ValidationOptions{
+		Exempt: []string{pb.OrderManagementService_CreateOrders_FullMethodName},
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptors.UnaryServerValidation(validationOpts)),
+		grpc.ChainStreamInterceptor(interceptors.StreamServerValidation(validationOpts)),
+	)
+	pb.RegisterOrderManagementServiceServer(s, srv)
+	return pb.NewOrderManagementServiceClient(dialBufconn(t, s))
+}
+
+// dialBufconn serves s over an in-process connection and returns a client
+// connection to it.
+func dialBufconn(t *testing.T, s *grpc.Server, opts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	opts = append(opts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, err := grpc.NewClient("passthrough:///bufconn", opts...)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// TestParallelClients runs clients creating, reading, packing and
+// cancelling orders at the same time. Run it with -race.
+func TestParallelClients(t *testing.T) {
+	client := newTestClient(t, newTestServer())
+	ctx := context.Background()
+
+	const clients, ordersPerClient = 8, 20
+	var wg sync.WaitGroup
+	for range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range ordersPerClient {
+				created, err := client.CreateOrder(ctx, &pb.CreateOrderRequest{Price: float32(i + 1)})
+				if err != nil {
+					t.Errorf("CreateOrder: %v", err)
+					return
+				}
+				if _, err := client.GetOrder(ctx, &wrappers.StringValue{Value: created.Id}); err != nil {
+					t.Errorf("GetOrder: %v", err)
+					return
+				}
+				if i%2 == 0 {
+					_, err = client.CancelOrder(ctx, &pb.CancelOrderRequest{Id: created.Id})
+				} else {
+					err = packOrder(ctx, client, created.Id)
+				}
+				if err != nil {
+					t.Errorf("failed to change order status: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stream, err := client.GetOrders(ctx, &pb.GetOrdersRequest{})
+	if err != nil {
+		t.Fatalf("GetOrders: %v", err)
+	}
+	counts := make(map[pb.OrderStatus]int)
+	for {
+		order, err := stream.Recv()
+		if err != nil {
+			break
+		}
+		counts[order.Status]++
+	}
+	want := map[pb.OrderStatus]int{
+		pb.OrderStatus_ORDER_STATUS_CANCELLED: clients * ordersPerClient / 2,
+		pb.OrderStatus_ORDER_STATUS_PACKED:    clients * ordersPerClient / 2,
+	}
+	for status, n := range want {
+		if counts[status] != n {
+			t.Errorf("got %d %s orders, want %d", counts[status], status, n)
+		}
+	}
+}
+
+// packOrder packs a single order with PackOrders.
+func packOrder(ctx context.Context, client pb.OrderManagementServiceClient, id string) error {
+	stream, err := client.PackOrders(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&pb.PackOrdersRequest{Id: id}); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	_, err = stream.Recv()
+	return err
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"ch3/svc/pkg/store"
+	"ch3/svc/pkg/watch"
+	pb "ch3/svc/protos/ordermgt/v1"
+	productpb "ch3/svc/protos/product_info/v1"
+
+	"github.com/google/uuid"
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// createOrdersModeMetadataKey selects how CreateOrders handles invalid
+	// orders: "best-effort" (default) creates all the valid orders of the
+	// stream, "all-or-nothing" creates none unless they are all valid.
+	createOrdersModeMetadataKey = "create-orders-mode"
+
+	createOrdersModeBestEffort   = "best-effort"
+	createOrdersModeAllOrNothing = "all-or-nothing"
+)
+
+// allOrNothingFromMetadata reports whether the CreateOrders stream asked for
+// all-or-nothing semantics.
+func allOrNothingFromMetadata(ctx context.Context) (bool, error) {
+	values := metadata.ValueFromIncomingContext(ctx, createOrdersModeMetadataKey)
+	if len(values) == 0 {
+		return false, nil
+	}
+	switch mode := values[len(values)-1]; mode {
+	case createOrdersModeBestEffort:
+		return false, nil
+	case createOrdersModeAllOrNothing:
+		return true, nil
+	default:
+		return false, invalidArgumentError("invalid create orders mode", &epb.BadRequest_FieldViolation{
+			Field:       createOrdersModeMetadataKey,
+			Description: fmt.Sprintf("Mode %q is not valid - expected %q or %q", mode, createOrdersModeBestEffort, createOrdersModeAllOrNothing),
+		})
+	}
+}
+
+// newOrder builds a new order from the requested price and line items,
+// whose field rules have already been checked. Orders with line items are
+// priced from the current product prices.
+func (s *server) newOrder(ctx context.Context, price float32, items []*pb.LineItem) (store.Order, error) {
+	if price != 0 && len(items) > 0 {
+		return store.Order{}, invalidArgumentError("invalid order", &epb.BadRequest_FieldViolation{
+			Field:       "price",
+			Description: "Price can't be set together with items - it's computed from the product prices",
+		})
+	}
+
+	order := store.Order{
+		Id:        uuid.NewString(),
+		Price:     price,
+		Status:    store.StatusCreated,
+		CreatedAt: time.Now().UTC(),
+	}
+	if len(items) == 0 {
+		return order, nil
+	}
+	var err error
+	order.Items, order.Price, err = s.resolveItems(ctx, items)
+	return order, err
+}
+
+// resolveItems looks up every line item product in the ProductInfoService,
+// freezing its current price, and returns the resolved items with their total.
+// Invalid items are reported all at once as field violations.
+func (s *server) resolveItems(ctx context.Context, items []*pb.LineItem) ([]store.OrderItem, float32, error) {
+	var (
+		resolved   = make([]store.OrderItem, 0, len(items))
+		total      float32
+		violations []*epb.BadRequest_FieldViolation
+	)
+	for i, item := range items {
+		product, err := s.products.GetProduct(ctx, &productpb.ProductID{Value: item.ProductId})
+		if status.Code(err) == codes.NotFound {
+			violations = append(violations, &epb.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("items[%d].product_id", i),
+				Description: fmt.Sprintf("Product id=%q does not exist", item.ProductId),
+			})
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to get product %s: %v", item.ProductId, err)
+			return nil, 0, productServiceError(err)
+		}
+		resolved = append(resolved, store.OrderItem{
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+			UnitPrice: product.Price,
+		})
+		total += product.Price * float32(item.Quantity)
+	}
+	if len(violations) > 0 {
+		return nil, 0, invalidArgumentError("invalid order items", violations...)
+	}
+	return resolved, total, nil
+}
+
+func orderItemsToPb(items []store.OrderItem) []*pb.OrderItem {
+	if len(items) == 0 {
+		return nil
+	}
+	pbItems := make([]*pb.OrderItem, len(items))
+	for i, item := range items {
+		pbItems[i] = &pb.OrderItem{ProductId: item.ProductId, Quantity: item.Quantity, UnitPrice: item.UnitPrice}
+	}
+	return pbItems
+}
+
+var orderStatusesToPb = map[store.OrderStatus]pb.OrderStatus{
+	store.StatusCreated:   pb.OrderStatus_ORDER_STATUS_CREATED,
+	store.StatusPacked:    pb.OrderStatus_ORDER_STATUS_PACKED,
+	store.StatusShipped:   pb.OrderStatus_ORDER_STATUS_SHIPPED,
+	store.StatusDelivered: pb.OrderStatus_ORDER_STATUS_DELIVERED,
+	store.StatusCancelled: pb.OrderStatus_ORDER_STATUS_CANCELLED,
+}
+
+func orderStatusToPb(s store.OrderStatus) pb.OrderStatus {
+	return orderStatusesToPb[s]
+}
+
+// orderEvent is an order change published to the WatchOrders watchers.
+type orderEvent struct {
+	eventType pb.OrderEventType
+	order     store.Order
+	time      time.Time
+}
+
+// publishOrderEvents returns the store.ChangeFunc publishing the order
+// writes to events. It runs under the lock of the store, so that revisions
+// follow the order of the writes.
+func publishOrderEvents(events *watch.Hub[orderEvent]) store.ChangeFunc {
+	return func(order store.Order, created bool) {
+		eventType := pb.OrderEventType_ORDER_EVENT_TYPE_UPDATED
+		switch {
+		case created:
+			eventType = pb.OrderEventType_ORDER_EVENT_TYPE_CREATED
+		case order.Status == store.StatusPacked:
+			eventType = pb.OrderEventType_ORDER_EVENT_TYPE_PACKED
+		case order.Status == store.StatusCancelled:
+			eventType = pb.OrderEventType_ORDER_EVENT_TYPE_CANCELLED
+		}
+		events.Publish(orderEvent{eventType: eventType, order: order, time: time.Now()})
+	}
+}
+
+func orderEventToPb(ev watch.Event[orderEvent]) *pb.OrderEvent {
+	return &pb.OrderEvent{
+		Revision:  ev.Revision,
+		Type:      ev.Value.eventType,
+		Order:     orderToPb(ev.Value.order),
+		EventTime: timestamppb.New(ev.Value.time),
+	}
+}
+
+func orderToPb(order store.Order) *pb.Order {
+	return &pb.Order{
+		Id:         order.Id,
+		Price:      order.Price,
+		Items:      orderItemsToPb(order.Items),
+		Status:     orderStatusToPb(order.Status),
+		CreateTime: timestamppb.New(order.CreatedAt),
+	}
+}
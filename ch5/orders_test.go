@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	pb "ch3/svc/protos/ordermgt/v1"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+)
+
+// TestWatchOrdersFollowsStoreWrites races status changes of the same orders
+// and checks that replaying their events ends on their stored status.
+func TestWatchOrdersFollowsStoreWrites(t *testing.T) {
+	srv := newTestServer()
+	client := newTestClient(t, srv)
+	ctx := context.Background()
+	ids := createTestOrders(t, client, 20)
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			packOrder(ctx, client, id)
+		}()
+		go func() {
+			defer wg.Done()
+			client.CancelOrder(ctx, &pb.CancelOrderRequest{Id: id})
+		}()
+	}
+	wg.Wait()
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := client.WatchOrders(watchCtx, &pb.WatchOrdersRequest{StartRevision: 1})
+	if err != nil {
+		t.Fatalf("WatchOrders: %v", err)
+	}
+	last := make(map[string]*pb.OrderEvent)
+	for revision := uint64(1); revision <= srv.events.Revision(); revision++ {
+		ev, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if ev.Revision != revision {
+			t.Fatalf("got revision %d, want %d", ev.Revision, revision)
+		}
+		last[ev.Order.Id] = ev
+	}
+
+	for _, id := range ids {
+		order, err := client.GetOrder(ctx, &wrappers.StringValue{Value: id})
+		if err != nil {
+			t.Fatalf("GetOrder: %v", err)
+		}
+		if ev := last[id]; ev == nil || ev.Order.Status != order.Status {
+			t.Errorf("order %s is %s, but its last event is %v", id, order.Status, ev)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"ch3/svc/pkg/store"
+	pb "ch3/svc/protos/ordermgt/v1"
+
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// packingPolicyMetadataKey carries the PackOrders packing policy as
+	// comma-separated key=value pairs, e.g. "fixed-count=3,flush-after-ms=500"
+	// or "max-total-price=100".
+	packingPolicyMetadataKey = "packing-policy"
+
+	defaultPackSize = 3
+)
+
+// packer groups the orders received by PackOrders into packs, keeping the
+// order they were added in.
+type packer interface {
+	// Add adds an order to the pending pack and returns the packs it completed.
+	Add(order *pb.PackedOrder) [][]*pb.PackedOrder
+	// Flush returns the pending partial pack, if any, and starts a new one.
+	Flush() []*pb.PackedOrder
+}
+
+// fixedCountPacker emits a pack every count orders.
+type fixedCountPacker struct {
+	count   int
+	pending []*pb.PackedOrder
+}
+
+func (p *fixedCountPacker) Add(order *pb.PackedOrder) [][]*pb.PackedOrder {
+	p.pending = append(p.pending, order)
+	if len(p.pending) < p.count {
+		return nil
+	}
+	return [][]*pb.PackedOrder{p.Flush()}
+}
+
+func (p *fixedCountPacker) Flush() []*pb.PackedOrder {
+	pack := p.pending
+	p.pending = nil
+	return pack
+}
+
+// maxTotalPricePacker emits a pack before its total price would exceed maxTotal.
+type maxTotalPricePacker struct {
+	maxTotal float32
+	total    float32
+	pending  []*pb.PackedOrder
+}
+
+func (p *maxTotalPricePacker) Add(order *pb.PackedOrder) [][]*pb.PackedOrder {
+	var packs [][]*pb.PackedOrder
+	if len(p.pending) > 0 && p.total+order.Price > p.maxTotal {
+		packs = append(packs, p.Flush())
+	}
+	p.pending = append(p.pending, order)
+	p.total += order.Price
+	if p.total >= p.maxTotal {
+		packs = append(packs, p.Flush())
+	}
+	return packs
+}
+
+func (p *maxTotalPricePacker) Flush() []*pb.PackedOrder {
+	pack := p.pending
+	p.pending = nil
+	p.total = 0
+	return pack
+}
+
+// newPacker builds the packer of a PackOrders stream along with its
+// inactivity flush window, zero meaning no time window.
+func newPacker(policy *pb.PackingPolicy) (packer, time.Duration, error) {
+	flushAfter := time.Duration(policy.GetFlushAfterMs()) * time.Millisecond
+	switch p := policy.GetPolicy().(type) {
+	case nil:
+		return &fixedCountPacker{count: defaultPackSize}, flushAfter, nil
+	case *pb.PackingPolicy_FixedCount:
+		if p.FixedCount == 0 {
+			return nil, 0, invalidArgumentError("invalid packing policy", &epb.BadRequest_FieldViolation{
+				Field:       "policy.fixed_count",
+				Description: "Fixed count must be positive",
+			})
+		}
+		return &fixedCountPacker{count: int(p.FixedCount)}, flushAfter, nil
+	case *pb.PackingPolicy_MaxTotalPrice:
+		if p.MaxTotalPrice <= 0 {
+			return nil, 0, invalidArgumentError("invalid packing policy", &epb.BadRequest_FieldViolation{
+				Field:       "policy.max_total_price",
+				Description: fmt.Sprintf("Max total price received (%f) is not valid - must be positive", p.MaxTotalPrice),
+			})
+		}
+		return &maxTotalPricePacker{maxTotal: p.MaxTotalPrice}, flushAfter, nil
+	default:
+		return nil, 0, invalidArgumentError("invalid packing policy", &epb.BadRequest_FieldViolation{
+			Field:       "policy",
+			Description: fmt.Sprintf("Packing policy %T is not supported", p),
+		})
+	}
+}
+
+// packingPolicyFromMetadata parses the packing policy sent in the request
+// metadata. It returns nil if the client didn't send one.
+func packingPolicyFromMetadata(ctx context.Context) (*pb.PackingPolicy, error) {
+	values := metadata.ValueFromIncomingContext(ctx, packingPolicyMetadataKey)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	policy := &pb.PackingPolicy{}
+	for _, pair := range strings.Split(strings.Join(values, ","), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+		var err error
+		switch key {
+		case "fixed-count":
+			var n uint64
+			n, err = strconv.ParseUint(value, 10, 32)
+			policy.Policy = &pb.PackingPolicy_FixedCount{FixedCount: uint32(n)}
+		case "max-total-price":
+			var f float64
+			f, err = strconv.ParseFloat(value, 32)
+			policy.Policy = &pb.PackingPolicy_MaxTotalPrice{MaxTotalPrice: float32(f)}
+		case "flush-after-ms":
+			var n uint64
+			n, err = strconv.ParseUint(value, 10, 32)
+			policy.FlushAfterMs = uint32(n)
+		default:
+			err = fmt.Errorf("unknown setting %q", key)
+		}
+		if err != nil {
+			return nil, invalidArgumentError("invalid packing policy", &epb.BadRequest_FieldViolation{
+				Field:       packingPolicyMetadataKey,
+				Description: fmt.Sprintf("Packing policy %q is not valid: %v", pair, err),
+			})
+		}
+	}
+	return policy, nil
+}
+
+// unpackOrders moves orders PackOrders marked PACKED but never sent back to
+// CREATED, so that they can be packed again.
+func (s *server) unpackOrders(ctx context.Context, ids []string) {
+	for _, id := range ids {
+		order, err := s.orders.Update(ctx, id, func(order *store.Order) error {
+			if order.Status != store.StatusPacked {
+				return &store.TransitionError{OrderId: order.Id, From: order.Status, To: store.StatusCreated}
+			}
+			order.Status = store.StatusCreated
+			return nil
+		})
+		if err != nil {
+			log.Printf("Failed to unpack order %s: %v", id, err)
+			continue
+		}
+		log.Printf("Order id = \"%s\" was not sent in a pack, it is %s again", order.Id, order.Status)
+	}
+}
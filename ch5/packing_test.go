@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	pb "ch3/svc/protos/ordermgt/v1"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// packIds returns the ids of the orders of each pack.
+func packIds(packs [][]*pb.PackedOrder) [][]string {
+	var ids [][]string
+	for _, pack := range packs {
+		var packIds []string
+		for _, order := range pack {
+			packIds = append(packIds, order.Id)
+		}
+		ids = append(ids, packIds)
+	}
+	return ids
+}
+
+// addAll adds the orders to p and returns the packs it emits, ending with
+// the flushed partial pack.
+func addAll(p packer, orders ...*pb.PackedOrder) [][]*pb.PackedOrder {
+	var packs [][]*pb.PackedOrder
+	for _, order := range orders {
+		packs = append(packs, p.Add(order)...)
+	}
+	if pack := p.Flush(); len(pack) > 0 {
+		packs = append(packs, pack)
+	}
+	return packs
+}
+
+func TestFixedCountPacker(t *testing.T) {
+	packs := addAll(&fixedCountPacker{count: 2},
+		&pb.PackedOrder{Id: "a"}, &pb.PackedOrder{Id: "b"}, &pb.PackedOrder{Id: "c"})
+	want := [][]string{{"a", "b"}, {"c"}}
+	if got := packIds(packs); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("packs = %v, want %v", got, want)
+	}
+}
+
+func TestMaxTotalPricePacker(t *testing.T) {
+	packs := addAll(&maxTotalPricePacker{maxTotal: 10},
+		&pb.PackedOrder{Id: "a", Price: 4},
+		&pb.PackedOrder{Id: "b", Price: 5},
+		// Would exceed the max total, starts a new pack.
+		&pb.PackedOrder{Id: "c", Price: 3},
+		// Priced above the max total, packed alone.
+		&pb.PackedOrder{Id: "d", Price: 12},
+		// Reaches the max total exactly.
+		&pb.PackedOrder{Id: "e", Price: 4},
+		&pb.PackedOrder{Id: "f", Price: 6},
+		&pb.PackedOrder{Id: "g", Price: 1},
+	)
+	want := [][]string{{"a", "b"}, {"c"}, {"d"}, {"e", "f"}, {"g"}}
+	if got := packIds(packs); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("packs = %v, want %v", got, want)
+	}
+}
+
+func TestNewPacker(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     *pb.PackingPolicy
+		wantCount  int
+		flushAfter time.Duration
+		wantErr    bool
+	}{
+		{name: "default", policy: nil, wantCount: defaultPackSize},
+		{
+			name:       "fixed count",
+			policy:     &pb.PackingPolicy{Policy: &pb.PackingPolicy_FixedCount{FixedCount: 5}, FlushAfterMs: 200},
+			wantCount:  5,
+			flushAfter: 200 * time.Millisecond,
+		},
+		{name: "max total price", policy: &pb.PackingPolicy{Policy: &pb.PackingPolicy_MaxTotalPrice{MaxTotalPrice: 10}}},
+		{name: "zero count", policy: &pb.PackingPolicy{Policy: &pb.PackingPolicy_FixedCount{}}, wantErr: true},
+		{name: "negative price", policy: &pb.PackingPolicy{Policy: &pb.PackingPolicy_MaxTotalPrice{MaxTotalPrice: -1}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, flushAfter, err := newPacker(tt.policy)
+			if tt.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("newPacker error = %v, want InvalidArgument", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newPacker: %v", err)
+			}
+			if flushAfter != tt.flushAfter {
+				t.Errorf("flushAfter = %v, want %v", flushAfter, tt.flushAfter)
+			}
+			if fixed, ok := p.(*fixedCountPacker); ok && fixed.count != tt.wantCount {
+				t.Errorf("count = %d, want %d", fixed.count, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestPackingPolicyFromMetadata(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(packingPolicyMetadataKey, "fixed-count=4, flush-after-ms=250"))
+	policy, err := packingPolicyFromMetadata(ctx)
+	if err != nil {
+		t.Fatalf("packingPolicyFromMetadata: %v", err)
+	}
+	if policy.GetFixedCount() != 4 || policy.FlushAfterMs != 250 {
+		t.Errorf("policy = %v, want fixed count 4 flushed after 250ms", policy)
+	}
+
+	if policy, err := packingPolicyFromMetadata(context.Background()); policy != nil || err != nil {
+		t.Errorf("packingPolicyFromMetadata without metadata = %v, %v, want nil, nil", policy, err)
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(packingPolicyMetadataKey, "boxes=2"))
+	if _, err := packingPolicyFromMetadata(ctx); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("packingPolicyFromMetadata of an unknown setting = %v, want InvalidArgument", err)
+	}
+}
+
+func createTestOrders(t *testing.T, client pb.OrderManagementServiceClient, n int) []string {
+	t.Helper()
+	var ids []string
+	for i := range n {
+		resp, err := client.CreateOrder(context.Background(), &pb.CreateOrderRequest{Price: float32(i + 1)})
+		if err != nil {
+			t.Fatalf("CreateOrder: %v", err)
+		}
+		ids = append(ids, resp.Id)
+	}
+	return ids
+}
+
+func TestPackOrdersFlushesAfterInactivity(t *testing.T) {
+	client := newTestClient(t, newTestServer())
+	ids := createTestOrders(t, client, 2)
+
+	const flushAfter = 100 * time.Millisecond
+	stream, err := client.PackOrders(context.Background())
+	if err != nil {
+		t.Fatalf("PackOrders: %v", err)
+	}
+	policy := &pb.PackingPolicy{Policy: &pb.PackingPolicy_FixedCount{FixedCount: 10}, FlushAfterMs: uint32(flushAfter.Milliseconds())}
+	if err := stream.Send(&pb.PackOrdersRequest{Policy: policy}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	start := time.Now()
+	for _, id := range ids {
+		if err := stream.Send(&pb.PackOrdersRequest{Id: id}); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	// The stream stays open: only the time window can emit the partial pack.
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < flushAfter {
+		t.Errorf("partial pack emitted after %v, before the %v window", elapsed, flushAfter)
+	}
+	if got := len(resp.Orders); got != len(ids) {
+		t.Errorf("partial pack has %d orders, want %d", got, len(ids))
+	}
+	stream.CloseSend()
+	if _, err := stream.Recv(); err == nil {
+		t.Error("got another pack after the flushed one")
+	}
+}
+
+func TestPackOrdersUnpacksUnsentOrders(t *testing.T) {
+	client := newTestClient(t, newTestServer())
+	ids := createTestOrders(t, client, 1)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), packingPolicyMetadataKey, "fixed-count=2")
+	stream, err := client.PackOrders(ctx)
+	if err != nil {
+		t.Fatalf("PackOrders: %v", err)
+	}
+	if err := stream.Send(&pb.PackOrdersRequest{Id: ids[0]}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	// An unknown order fails the stream before the pending pack is sent.
+	if err := stream.Send(&pb.PackOrdersRequest{Id: "00000000-0000-0000-0000-000000000000"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.NotFound {
+		t.Fatalf("Recv error = %v, want NotFound", err)
+	}
+
+	order, err := client.GetOrder(context.Background(), &wrappers.StringValue{Value: ids[0]})
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if order.Status != pb.OrderStatus_ORDER_STATUS_CREATED {
+		t.Errorf("unsent order is %s, want it CREATED again", order.Status)
+	}
+	if err := packOrder(context.Background(), client, ids[0]); err != nil {
+		t.Errorf("failed to pack the order again: %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+// Package credentials provides the per-RPC credentials of the clients.
+package credentials
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// BearerToken sends Token in the authorization metadata of every RPC.
+// Tokens are only sent over secure connections unless AllowInsecure is set.
+type BearerToken struct {
+	Token         string
+	AllowInsecure bool
+}
+
+var _ credentials.PerRPCCredentials = BearerToken{}
+
+func (t BearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.Token}, nil
+}
+
+func (t BearerToken) RequireTransportSecurity() bool {
+	return !t.AllowInsecure
+}
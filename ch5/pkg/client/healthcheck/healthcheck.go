@@ -0,0 +1,22 @@
+// Package healthcheck configures client connections to only send calls to
+// the servers reporting their service SERVING on grpc.health.v1.
+package healthcheck
+
+import (
+	"fmt"
+
+	// Registers the client-side health checking function.
+	_ "google.golang.org/grpc/health"
+)
+
+// ServiceConfig is the service config of client connections checking the
+// health of service, to pass to grpc.WithDefaultServiceConfig: calls are
+// balanced over the backends reporting it SERVING, leaving out the ones
+// shutting down or unhealthy. pick_first, the default policy, doesn't check
+// health.
+func ServiceConfig(service string) string {
+	return fmt.Sprintf(`{
+	"loadBalancingConfig": [{"round_robin": {}}],
+	"healthCheckConfig": {"serviceName": %q}
+}`, service)
+}
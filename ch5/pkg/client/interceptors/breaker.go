@@ -0,0 +1,359 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets the calls through, counting their failures.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails the calls fast until the cool-down is over.
+	BreakerOpen
+	// BreakerHalfOpen lets a few trial calls through to find out whether
+	// the server recovered.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("BreakerState(%d)", int(s))
+	}
+}
+
+// DefaultBreakerFailureCodes are the status codes counted as failures when
+// BreakerOptions.IsFailure is nil: the ones telling the server is down or
+// overwhelmed rather than the request is wrong.
+var DefaultBreakerFailureCodes = []codes.Code{
+	codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown,
+}
+
+// BreakerOptions configures the circuit breakers. Zero fields take the
+// default values.
+type BreakerOptions struct {
+	// FailureRatio of the calls over a Window opens the circuit once at
+	// least MinRequests calls were made. Default to 0.5, 5 and 10s.
+	FailureRatio float64
+	MinRequests  int
+	Window       time.Duration
+	// CoolDown is how long the circuit stays open before letting trial
+	// calls through. Defaults to 5s.
+	CoolDown time.Duration
+	// HalfOpenRequests is the number of trial calls that must succeed to
+	// close the circuit again. Defaults to 1.
+	HalfOpenRequests int
+	// IsFailure reports whether the error of a call counts as a failure.
+	// Defaults to the DefaultBreakerFailureCodes.
+	IsFailure func(error) bool
+	// OnStateChange is called when the circuit of a method changes state.
+	// The changes are logged when nil.
+	OnStateChange func(target, method string, from, to BreakerState)
+}
+
+func (o BreakerOptions) withDefaults() BreakerOptions {
+	if o.FailureRatio <= 0 {
+		o.FailureRatio = 0.5
+	}
+	if o.MinRequests <= 0 {
+		o.MinRequests = 5
+	}
+	if o.Window <= 0 {
+		o.Window = 10 * time.Second
+	}
+	if o.CoolDown <= 0 {
+		o.CoolDown = 5 * time.Second
+	}
+	if o.HalfOpenRequests <= 0 {
+		o.HalfOpenRequests = 1
+	}
+	if o.IsFailure == nil {
+		o.IsFailure = func(err error) bool {
+			return slices.Contains(DefaultBreakerFailureCodes, status.Code(err))
+		}
+	}
+	if o.OnStateChange == nil {
+		o.OnStateChange = func(target, method string, from, to BreakerState) {
+			slog.Warn("circuit breaker changed state",
+				slog.String("grpc.target", target),
+				slog.String("grpc.method", method),
+				slog.String("from", from.String()),
+				slog.String("to", to.String()),
+			)
+		}
+	}
+	return o
+}
+
+// CircuitBreakers keeps a circuit breaker per target and method, failing the
+// calls fast with Unavailable while the circuit is open. It is a
+// prometheus.Collector exporting the state of the circuits.
+type CircuitBreakers struct {
+	opts  BreakerOptions
+	state *prometheus.Desc
+
+	mu       sync.Mutex
+	breakers map[breakerKey]*breaker
+}
+
+type breakerKey struct {
+	target, method string
+}
+
+func NewCircuitBreakers(opts BreakerOptions) *CircuitBreakers {
+	return &CircuitBreakers{
+		opts: opts.withDefaults(),
+		state: prometheus.NewDesc("grpc_client_circuit_breaker_state",
+			"State of the circuit breaker of the method: 0 closed, 1 open, 2 half-open.",
+			[]string{"grpc_target", "grpc_service", "grpc_method"}, nil),
+		breakers: make(map[breakerKey]*breaker),
+	}
+}
+
+// State returns the state of the circuit of the full method on target.
+func (cb *CircuitBreakers) State(target, method string) BreakerState {
+	cb.mu.Lock()
+	b, ok := cb.breakers[breakerKey{target, method}]
+	cb.mu.Unlock()
+	if !ok {
+		return BreakerClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (cb *CircuitBreakers) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cb.state
+}
+
+func (cb *CircuitBreakers) Collect(ch chan<- prometheus.Metric) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	for key, b := range cb.breakers {
+		b.mu.Lock()
+		state := b.state
+		b.mu.Unlock()
+		service, method := splitMethod(key.method)
+		ch <- prometheus.MustNewConstMetric(cb.state, prometheus.GaugeValue, float64(state), key.target, service, method)
+	}
+}
+
+// UnaryClientInterceptor guards unary calls with the circuit breakers.
+func (cb *CircuitBreakers) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		b := cb.breaker(cc.Target(), method)
+		generation, err := b.allow()
+		if err != nil {
+			return err
+		}
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		b.record(generation, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor guards streaming calls with the circuit breakers.
+// The outcome of a stream is recorded once it ends. Streams the caller
+// abandons are recorded as cancelled once their context is done, which
+// frees their half-open trial.
+func (cb *CircuitBreakers) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		b := cb.breaker(cc.Target(), method)
+		generation, err := b.allow()
+		if err != nil {
+			return nil, err
+		}
+		s, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			b.record(generation, err)
+			return nil, err
+		}
+		stream := &breakerStream{ClientStream: s, desc: desc}
+		// Watch the context of the caller rather than the one of the
+		// stream, which is also done when the stream ends normally.
+		stop := context.AfterFunc(ctx, func() {
+			stream.finishOnce.Do(func() { b.record(generation, status.FromContextError(ctx.Err()).Err()) })
+		})
+		stream.finish = func(err error) {
+			stop()
+			b.record(generation, err)
+		}
+		return stream, nil
+	}
+}
+
+func (cb *CircuitBreakers) breaker(target, method string) *breaker {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	key := breakerKey{target, method}
+	b, ok := cb.breakers[key]
+	if !ok {
+		b = &breaker{opts: &cb.opts, target: target, method: method, windowStart: time.Now()}
+		cb.breakers[key] = b
+	}
+	return b
+}
+
+// breakerStream records the outcome of a stream once its status is known.
+type breakerStream struct {
+	grpc.ClientStream
+	desc       *grpc.StreamDesc
+	finish     func(error)
+	finishOnce sync.Once
+}
+
+func (s *breakerStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		if !s.desc.ServerStreams {
+			// The single response ends the call.
+			s.finishOnce.Do(func() { s.finish(nil) })
+		}
+	case errors.Is(err, io.EOF):
+		s.finishOnce.Do(func() { s.finish(nil) })
+	default:
+		s.finishOnce.Do(func() { s.finish(err) })
+	}
+	return err
+}
+
+// breaker is the circuit breaker of one method on one target.
+type breaker struct {
+	opts           *BreakerOptions
+	target, method string
+
+	mu    sync.Mutex
+	state BreakerState
+	// generation changes with the state, so that the outcomes of calls
+	// allowed in a previous state are ignored
+	generation         uint64
+	windowStart        time.Time
+	requests, failures int
+	openedAt           time.Time
+	// trials and successes count the calls of the half-open state
+	trials, successes int
+}
+
+// allow returns the generation of the call to record, or the Unavailable
+// error failing it fast.
+func (b *breaker) allow() (uint64, error) {
+	b.mu.Lock()
+	now := time.Now()
+	var from BreakerState
+	changed := false
+	switch b.state {
+	case BreakerClosed:
+		if now.Sub(b.windowStart) >= b.opts.Window {
+			b.windowStart, b.requests, b.failures = now, 0, 0
+		}
+	case BreakerOpen:
+		if remaining := b.opts.CoolDown - now.Sub(b.openedAt); remaining > 0 {
+			b.mu.Unlock()
+			return 0, b.openError(remaining)
+		}
+		from, changed = b.setState(BreakerHalfOpen), true
+	}
+	if b.state == BreakerHalfOpen {
+		if b.trials >= b.opts.HalfOpenRequests {
+			b.mu.Unlock()
+			return 0, b.openError(0)
+		}
+		b.trials++
+	}
+	generation := b.generation
+	b.mu.Unlock()
+	if changed {
+		b.opts.OnStateChange(b.target, b.method, from, BreakerHalfOpen)
+	}
+	return generation, nil
+}
+
+// record counts the outcome of a call allowed in generation.
+func (b *breaker) record(generation uint64, err error) {
+	failed := err != nil && b.opts.IsFailure(err)
+	b.mu.Lock()
+	if generation != b.generation {
+		b.mu.Unlock()
+		return
+	}
+	from, to := b.state, b.state
+	switch b.state {
+	case BreakerClosed:
+		b.requests++
+		if failed {
+			b.failures++
+		}
+		if b.requests >= b.opts.MinRequests && float64(b.failures)/float64(b.requests) >= b.opts.FailureRatio {
+			b.setState(BreakerOpen)
+		}
+	case BreakerHalfOpen:
+		switch {
+		case failed:
+			b.setState(BreakerOpen)
+		case err != nil && status.Code(err) == codes.Canceled:
+			// Tells nothing about the server, free the trial for another call.
+			b.trials--
+		default:
+			b.successes++
+			if b.successes >= b.opts.HalfOpenRequests {
+				b.setState(BreakerClosed)
+			}
+		}
+	}
+	to = b.state
+	b.mu.Unlock()
+	if from != to {
+		b.opts.OnStateChange(b.target, b.method, from, to)
+	}
+}
+
+// setState moves to state and returns the previous one. b.mu must be held.
+func (b *breaker) setState(state BreakerState) BreakerState {
+	from := b.state
+	now := time.Now()
+	b.state = state
+	b.generation++
+	b.requests, b.failures, b.windowStart = 0, 0, now
+	b.trials, b.successes = 0, 0
+	if state == BreakerOpen {
+		b.openedAt = now
+	}
+	return from
+}
+
+// openError fails a call fast, telling the caller when to retry.
+func (b *breaker) openError(retryAfter time.Duration) error {
+	errorStatus := status.Newf(codes.Unavailable, "circuit breaker is open for %s", b.method)
+	if retryAfter <= 0 {
+		return errorStatus.Err()
+	}
+	ds, err := errorStatus.WithDetails(&epb.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if err != nil {
+		return errorStatus.Err()
+	}
+	return ds.Err()
+}
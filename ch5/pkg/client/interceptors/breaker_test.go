@@ -0,0 +1,193 @@
+package interceptors
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"ch3/svc/pkg/rpcerr"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stateChanges records the state changes of the circuits.
+type stateChanges struct {
+	mu      sync.Mutex
+	changes []BreakerState
+}
+
+func (c *stateChanges) record(_, _ string, _, to BreakerState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.changes = append(c.changes, to)
+}
+
+func (c *stateChanges) get() []BreakerState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]BreakerState(nil), c.changes...)
+}
+
+// newTestBreakers opens the circuits after two calls failing out of four,
+// for a cool-down of coolDown.
+func newTestBreakers(coolDown time.Duration) (*CircuitBreakers, *stateChanges) {
+	changes := &stateChanges{}
+	return NewCircuitBreakers(BreakerOptions{
+		FailureRatio:  0.5,
+		MinRequests:   4,
+		Window:        time.Minute,
+		CoolDown:      coolDown,
+		OnStateChange: changes.record,
+	}), changes
+}
+
+func invokerReturning(err error) grpc.UnaryInvoker {
+	return func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		return err
+	}
+}
+
+// openCircuit fails enough calls through interceptor to open the circuit.
+func openCircuit(t *testing.T, interceptor grpc.UnaryClientInterceptor, cc *grpc.ClientConn) {
+	t.Helper()
+	for _, err := range []error{nil, nil, status.Error(codes.Unavailable, "down"), status.Error(codes.Internal, "boom")} {
+		interceptor(context.Background(), testMethod, nil, nil, cc, invokerReturning(err))
+	}
+}
+
+func TestBreakerOpensOnFailureRatio(t *testing.T) {
+	cb, changes := newTestBreakers(time.Minute)
+	interceptor := cb.UnaryClientInterceptor()
+	cc := newTestConn(t)
+
+	// Errors telling the request is wrong aren't failures.
+	for range 4 {
+		interceptor(context.Background(), "/invalid", nil, nil, cc, invokerReturning(status.Error(codes.InvalidArgument, "bad")))
+	}
+	if state := cb.State(cc.Target(), "/invalid"); state != BreakerClosed {
+		t.Fatalf("state after invalid requests = %s, want closed", state)
+	}
+
+	openCircuit(t, interceptor, cc)
+	if state := cb.State(cc.Target(), testMethod); state != BreakerOpen {
+		t.Fatalf("state = %s, want open", state)
+	}
+	called := false
+	err := interceptor(context.Background(), testMethod, nil, nil, cc, func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		called = true
+		return nil
+	})
+	if called {
+		t.Error("open circuit let the call through")
+	}
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("error = %v, want Unavailable", err)
+	}
+	if delay, ok := rpcerr.RetryDelay(err); !ok || delay <= 0 || delay > time.Minute {
+		t.Errorf("RetryInfo delay = %v, %t, want the remaining cool-down", delay, ok)
+	}
+	// The circuits are per method.
+	if err := interceptor(context.Background(), "/other", nil, nil, cc, invokerReturning(nil)); err != nil {
+		t.Errorf("call to another method failed: %v", err)
+	}
+	if got := changes.get(); len(got) != 1 || got[0] != BreakerOpen {
+		t.Errorf("state changes = %v, want [open]", got)
+	}
+}
+
+func TestBreakerHalfOpenTrial(t *testing.T) {
+	const coolDown = 20 * time.Millisecond
+	tests := []struct {
+		name     string
+		trialErr error
+		want     BreakerState
+	}{
+		{name: "success closes", trialErr: nil, want: BreakerClosed},
+		{name: "failure reopens", trialErr: status.Error(codes.Unavailable, "down"), want: BreakerOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb, changes := newTestBreakers(coolDown)
+			interceptor := cb.UnaryClientInterceptor()
+			cc := newTestConn(t)
+			openCircuit(t, interceptor, cc)
+			time.Sleep(coolDown)
+
+			// Only one trial runs at a time.
+			trialStarted, release := make(chan struct{}), make(chan struct{})
+			done := make(chan error)
+			go func() {
+				done <- interceptor(context.Background(), testMethod, nil, nil, cc, func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+					close(trialStarted)
+					<-release
+					return tt.trialErr
+				})
+			}()
+			<-trialStarted
+			if err := interceptor(context.Background(), testMethod, nil, nil, cc, invokerReturning(nil)); status.Code(err) != codes.Unavailable {
+				t.Errorf("call during the trial = %v, want Unavailable", err)
+			}
+			close(release)
+			<-done
+
+			if state := cb.State(cc.Target(), testMethod); state != tt.want {
+				t.Errorf("state = %s, want %s", state, tt.want)
+			}
+			want := []BreakerState{BreakerOpen, BreakerHalfOpen, tt.want}
+			if got := changes.get(); !slices.Equal(got, want) {
+				t.Errorf("state changes = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// idleStream is a server stream that never receives anything.
+type idleStream struct {
+	grpc.ClientStream
+	ctx context.Context
+}
+
+func (s *idleStream) Context() context.Context { return s.ctx }
+
+func (s *idleStream) RecvMsg(any) error {
+	<-s.ctx.Done()
+	return status.FromContextError(s.ctx.Err()).Err()
+}
+
+func TestBreakerFreesTrialOfAbandonedStream(t *testing.T) {
+	const coolDown = 20 * time.Millisecond
+	cb, _ := newTestBreakers(coolDown)
+	cc := newTestConn(t)
+	openCircuit(t, cb.UnaryClientInterceptor(), cc)
+	time.Sleep(coolDown)
+
+	streamer := func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &idleStream{ctx: ctx}, nil
+	}
+	desc := &grpc.StreamDesc{ServerStreams: true}
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := cb.StreamClientInterceptor()(ctx, desc, cc, testMethod, streamer); err != nil {
+		t.Fatalf("trial stream failed: %v", err)
+	}
+	// The caller walks away without reading the stream.
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		err := cb.UnaryClientInterceptor()(context.Background(), testMethod, nil, nil, cc, invokerReturning(nil))
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("trial of the abandoned stream never freed: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if state := cb.State(cc.Target(), testMethod); state != BreakerClosed {
+		t.Errorf("state = %s, want closed", state)
+	}
+}
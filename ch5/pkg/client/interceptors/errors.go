@@ -0,0 +1,53 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"ch3/svc/pkg/rpcerr"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryClientErrors annotates the errors of unary calls with their method.
+// The annotated errors keep their status, so that status.Code and the
+// helpers of pkg/rpcerr still see the code and details sent by the server.
+func UnaryClientErrors() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return rpcerr.Wrap(invoker(ctx, method, req, reply, cc, opts...), method[1:])
+	}
+}
+
+// StreamClientErrors annotates the errors of streaming calls with their
+// method like UnaryClientErrors. The io.EOF ending a stream is returned
+// as is.
+func StreamClientErrors() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		s, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, rpcerr.Wrap(err, method[1:])
+		}
+		return &errorsStream{ClientStream: s, method: method[1:]}, nil
+	}
+}
+
+type errorsStream struct {
+	grpc.ClientStream
+	method string
+}
+
+func (s *errorsStream) SendMsg(m any) error {
+	return s.annotate(s.ClientStream.SendMsg(m))
+}
+
+func (s *errorsStream) RecvMsg(m any) error {
+	return s.annotate(s.ClientStream.RecvMsg(m))
+}
+
+func (s *errorsStream) annotate(err error) error {
+	if errors.Is(err, io.EOF) {
+		return err
+	}
+	return rpcerr.Wrap(err, s.method)
+}
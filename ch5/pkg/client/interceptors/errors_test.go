@@ -0,0 +1,68 @@
+package interceptors
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"ch3/svc/pkg/rpcerr"
+
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryClientErrorsKeepsStatus(t *testing.T) {
+	invalid := rpcerr.InvalidArgument("invalid order", &epb.BadRequest_FieldViolation{Field: "price"})
+	err := UnaryClientErrors()(context.Background(), testMethod, nil, nil, newTestConn(t), invokerReturning(invalid))
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("status.Code = %s, want InvalidArgument", status.Code(err))
+	}
+	if violations := rpcerr.FieldViolations(err); len(violations) != 1 || violations[0].Field != "price" {
+		t.Errorf("FieldViolations = %v, want price", violations)
+	}
+	if want := "ecommerce.v1.OrderManagementService/GetOrder: invalid order"; status.Convert(err).Message() != want {
+		t.Errorf("message = %q, want %q", status.Convert(err).Message(), want)
+	}
+
+	if err := UnaryClientErrors()(context.Background(), testMethod, nil, nil, newTestConn(t), invokerReturning(nil)); err != nil {
+		t.Errorf("successful call = %v, want nil", err)
+	}
+}
+
+// endedStream is a stream that already ended with err.
+type endedStream struct {
+	grpc.ClientStream
+	err error
+}
+
+func (s *endedStream) RecvMsg(any) error { return s.err }
+
+// recvStream opens a stream whose RecvMsg fails with recvErr through the
+// StreamClientErrors interceptor and returns the error of RecvMsg.
+func recvStream(t *testing.T, recvErr error) error {
+	t.Helper()
+	streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &endedStream{err: recvErr}, nil
+	}
+	s, err := StreamClientErrors()(context.Background(), &grpc.StreamDesc{ServerStreams: true}, newTestConn(t), testMethod, streamer)
+	if err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+	return s.RecvMsg(nil)
+}
+
+func TestStreamClientErrors(t *testing.T) {
+	// Generated code and callers compare the end of streams with io.EOF.
+	if err := recvStream(t, io.EOF); err != io.EOF {
+		t.Errorf("RecvMsg at the end of the stream = %v, want io.EOF as is", err)
+	}
+	err := recvStream(t, status.Error(codes.NotFound, "order not found"))
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("status.Code = %s, want NotFound", status.Code(err))
+	}
+	if want := "ecommerce.v1.OrderManagementService/GetOrder: order not found"; status.Convert(err).Message() != want {
+		t.Errorf("message = %q, want %q", status.Convert(err).Message(), want)
+	}
+}
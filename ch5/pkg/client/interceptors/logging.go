@@ -0,0 +1,208 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ch3/svc/pkg/redact"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// LoggingOptions configures the logging interceptors.
+type LoggingOptions struct {
+	// Logger receives the records. slog.Default() is used when nil.
+	Logger *slog.Logger
+	// Level maps the status code of a finished RPC to the level of its
+	// record. DefaultLevel is used when nil.
+	Level func(codes.Code) slog.Level
+	// Payloads adds the request and response messages, with their sensitive
+	// fields redacted, to the records of unary RPCs, and logs every message
+	// of streaming RPCs at Debug.
+	Payloads bool
+}
+
+// DefaultLevel logs successful RPCs at Info, the ones failed because of the
+// caller at Warn and the others at Error, like the server DefaultLevel.
+func DefaultLevel(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
+		codes.PermissionDenied, codes.Unauthenticated, codes.ResourceExhausted,
+		codes.FailedPrecondition, codes.Aborted, codes.OutOfRange:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+func (o LoggingOptions) withDefaults() LoggingOptions {
+	if o.Logger == nil {
+		o.Logger = slog.Default()
+	}
+	if o.Level == nil {
+		o.Level = DefaultLevel
+	}
+	return o
+}
+
+// UnaryClientLogging logs one record per unary RPC once it completes.
+func UnaryClientLogging(opts LoggingOptions) grpc.UnaryClientInterceptor {
+	opts = opts.withDefaults()
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		start := time.Now()
+		var p peer.Peer
+		err := invoker(ctx, method, req, reply, cc, append(callOpts, grpc.Peer(&p))...)
+		attrs := []slog.Attr{
+			slog.Int("grpc.request.size", messageSize(req)),
+		}
+		if err == nil {
+			attrs = append(attrs, slog.Int("grpc.response.size", messageSize(reply)))
+		}
+		if opts.Payloads {
+			attrs = append(attrs, slog.Any("grpc.request.content", redact.Value{V: req}))
+			if err == nil {
+				attrs = append(attrs, slog.Any("grpc.response.content", redact.Value{V: reply}))
+			}
+		}
+		logCall(ctx, opts, "finished unary call", cc.Target(), &p, method, start, err, attrs...)
+		return err
+	}
+}
+
+// StreamClientLogging logs one record per streaming RPC once it completes,
+// with the number and total size of the messages sent and received. Streams
+// abandoned by the caller without reading them to the end aren't logged.
+func StreamClientLogging(opts LoggingOptions) grpc.StreamClientInterceptor {
+	opts = opts.withDefaults()
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		s, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			logCall(ctx, opts, "finished streaming call", cc.Target(), nil, method, start, err)
+			return nil, err
+		}
+		stream := &loggingStream{ClientStream: s, desc: desc, opts: opts, fullMethod: method}
+		stream.finish = func(err error) {
+			p, _ := peer.FromContext(s.Context())
+			logCall(ctx, opts, "finished streaming call", cc.Target(), p, method, start, err,
+				slog.Bool("grpc.client_stream", desc.ClientStreams),
+				slog.Bool("grpc.server_stream", desc.ServerStreams),
+				slog.Int64("grpc.request.count", stream.sent.Load()),
+				slog.Int64("grpc.request.size", stream.sentBytes.Load()),
+				slog.Int64("grpc.response.count", stream.received.Load()),
+				slog.Int64("grpc.response.size", stream.receivedBytes.Load()),
+			)
+		}
+		return stream, nil
+	}
+}
+
+// loggingStream counts the messages going through a client stream and logs
+// the call once its status is known.
+type loggingStream struct {
+	grpc.ClientStream
+	desc                    *grpc.StreamDesc
+	opts                    LoggingOptions
+	fullMethod              string
+	sent, sentBytes         atomic.Int64
+	received, receivedBytes atomic.Int64
+	finish                  func(error)
+	finishOnce              sync.Once
+}
+
+func (s *loggingStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.sent.Add(1)
+		s.sentBytes.Add(int64(messageSize(m)))
+		s.logMessage("sent message", m)
+	}
+	return err
+}
+
+func (s *loggingStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		s.received.Add(1)
+		s.receivedBytes.Add(int64(messageSize(m)))
+		s.logMessage("received message", m)
+		if !s.desc.ServerStreams {
+			// The single response ends the call.
+			s.finishOnce.Do(func() { s.finish(nil) })
+		}
+	case errors.Is(err, io.EOF):
+		s.finishOnce.Do(func() { s.finish(nil) })
+	default:
+		s.finishOnce.Do(func() { s.finish(err) })
+	}
+	return err
+}
+
+func (s *loggingStream) logMessage(msg string, m any) {
+	if !s.opts.Payloads {
+		return
+	}
+	service, method := splitMethod(s.fullMethod)
+	s.opts.Logger.LogAttrs(s.Context(), slog.LevelDebug, msg,
+		slog.String("grpc.service", service),
+		slog.String("grpc.method", method),
+		slog.Any("grpc.content", redact.Value{V: m}),
+	)
+}
+
+func logCall(ctx context.Context, opts LoggingOptions, msg, target string, p *peer.Peer, fullMethod string, start time.Time, err error, extra ...slog.Attr) {
+	st := status.Convert(err)
+	level := opts.Level(st.Code())
+	if !opts.Logger.Enabled(ctx, level) {
+		return
+	}
+	service, method := splitMethod(fullMethod)
+	attrs := []slog.Attr{
+		slog.String("grpc.service", service),
+		slog.String("grpc.method", method),
+		slog.String("grpc.target", target),
+		slog.String("grpc.code", st.Code().String()),
+		slog.Float64("grpc.duration_ms", float64(time.Since(start))/float64(time.Millisecond)),
+	}
+	if p != nil && p.Addr != nil {
+		attrs = append(attrs, slog.String("peer.address", p.Addr.String()))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("grpc.error", st.Message()))
+	}
+	attrs = append(attrs, extra...)
+	opts.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// splitMethod splits "/package.Service/Method" into its service and method.
+func splitMethod(fullMethod string) (string, string) {
+	service, method := path.Split(fullMethod)
+	if len(service) < 2 {
+		return "", method
+	}
+	return service[1 : len(service)-1], method
+}
+
+func messageSize(m any) int {
+	if pm, ok := m.(proto.Message); ok {
+		return proto.Size(pm)
+	}
+	return 0
+}
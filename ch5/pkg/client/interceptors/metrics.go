@@ -0,0 +1,178 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	unaryType        = "unary"
+	clientStreamType = "client_stream"
+	serverStreamType = "server_stream"
+	bidiStreamType   = "bidi_stream"
+)
+
+// ClientMetrics records Prometheus metrics of the RPCs made by a client:
+// the number of started and completed RPCs by status code, their latency
+// and the number of stream messages sent and received.
+// It is a prometheus.Collector to register with a prometheus.Registerer.
+type ClientMetrics struct {
+	started     *prometheus.CounterVec
+	handled     *prometheus.CounterVec
+	handlingSec *prometheus.HistogramVec
+	msgReceived *prometheus.CounterVec
+	msgSent     *prometheus.CounterVec
+}
+
+func NewClientMetrics() *ClientMetrics {
+	labels := []string{"grpc_type", "grpc_service", "grpc_method"}
+	return &ClientMetrics{
+		started: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_client_started_total",
+			Help: "Total number of RPCs started on the client.",
+		}, labels),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_client_handled_total",
+			Help: "Total number of RPCs completed by the client, regardless of success or failure.",
+		}, append(labels, "grpc_code")),
+		handlingSec: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_client_handling_seconds",
+			Help:    "Histogram of response latency (seconds) of the RPCs until they are completed by the client.",
+			Buckets: prometheus.DefBuckets,
+		}, labels),
+		msgReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_client_msg_received_total",
+			Help: "Total number of RPC messages received by the client.",
+		}, labels),
+		msgSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_client_msg_sent_total",
+			Help: "Total number of RPC messages sent by the client.",
+		}, labels),
+	}
+}
+
+func (m *ClientMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.started.Describe(ch)
+	m.handled.Describe(ch)
+	m.handlingSec.Describe(ch)
+	m.msgReceived.Describe(ch)
+	m.msgSent.Describe(ch)
+}
+
+func (m *ClientMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.started.Collect(ch)
+	m.handled.Collect(ch)
+	m.handlingSec.Collect(ch)
+	m.msgReceived.Collect(ch)
+	m.msgSent.Collect(ch)
+}
+
+// UnaryClientInterceptor records the metrics of unary RPCs.
+func (m *ClientMetrics) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		r := m.start(unaryType, method)
+		r.msgSent.Inc()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			r.msgReceived.Inc()
+		}
+		r.finish(err)
+		return err
+	}
+}
+
+// StreamClientInterceptor records the metrics of streaming RPCs. Streams
+// abandoned by the caller without reading them to the end are counted as
+// started but not as completed.
+func (m *ClientMetrics) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		r := m.start(rpcType(desc.ClientStreams, desc.ServerStreams), method)
+		s, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			r.finish(err)
+			return nil, err
+		}
+		return &metricsStream{ClientStream: s, desc: desc, r: r}, nil
+	}
+}
+
+// rpcMetrics holds the metrics of one RPC, resolved when it starts.
+type rpcMetrics struct {
+	m                    *ClientMetrics
+	labels               []string
+	start                time.Time
+	msgReceived, msgSent prometheus.Counter
+}
+
+func (m *ClientMetrics) start(typ, fullMethod string) *rpcMetrics {
+	service, method := splitMethod(fullMethod)
+	r := &rpcMetrics{
+		m:           m,
+		labels:      []string{typ, service, method},
+		start:       time.Now(),
+		msgReceived: m.msgReceived.WithLabelValues(typ, service, method),
+		msgSent:     m.msgSent.WithLabelValues(typ, service, method),
+	}
+	m.started.WithLabelValues(r.labels...).Inc()
+	return r
+}
+
+func (r *rpcMetrics) finish(err error) {
+	code := status.Code(err).String()
+	r.m.handled.WithLabelValues(append(r.labels, code)...).Inc()
+	r.m.handlingSec.WithLabelValues(r.labels...).Observe(time.Since(r.start).Seconds())
+}
+
+// metricsStream counts the messages going through a client stream and
+// records its completion once its status is known.
+type metricsStream struct {
+	grpc.ClientStream
+	desc       *grpc.StreamDesc
+	r          *rpcMetrics
+	finishOnce sync.Once
+}
+
+func (s *metricsStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.r.msgSent.Inc()
+	}
+	return err
+}
+
+func (s *metricsStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		s.r.msgReceived.Inc()
+		if !s.desc.ServerStreams {
+			// The single response ends the call.
+			s.finishOnce.Do(func() { s.r.finish(nil) })
+		}
+	case errors.Is(err, io.EOF):
+		s.finishOnce.Do(func() { s.r.finish(nil) })
+	default:
+		s.finishOnce.Do(func() { s.r.finish(err) })
+	}
+	return err
+}
+
+func rpcType(clientStream, serverStream bool) string {
+	switch {
+	case clientStream && serverStream:
+		return bidiStreamType
+	case clientStream:
+		return clientStreamType
+	case serverStream:
+		return serverStreamType
+	default:
+		return unaryType
+	}
+}
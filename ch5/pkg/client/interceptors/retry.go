@@ -0,0 +1,139 @@
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"ch3/svc/pkg/idempotency"
+	"ch3/svc/pkg/rpcerr"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DefaultRetryableCodes are the status codes retried for the methods
+// without retryable codes of their own.
+var DefaultRetryableCodes = []codes.Code{codes.Unavailable}
+
+// RetryOptions configures the retry interceptor. Zero fields take the
+// default values.
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of attempts of a call, the first
+	// one included. Defaults to 3.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, multiplied by
+	// Multiplier after every retry up to MaxBackoff. Default to 100ms, 5s
+	// and 2.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter randomizes the delays by up to this fraction, e.g. 0.2 for
+	// ±20%, so that clients failing together don't retry together.
+	// Defaults to 0.2.
+	Jitter float64
+	// RetryableCodes lists the status codes to retry by full method name.
+	// DefaultRetryableCodes is used for the methods not listed.
+	RetryableCodes map[string][]codes.Code
+	// NonIdempotent lists the full names of the methods that aren't safe to
+	// call twice. They're only retried when the request carries an
+	// idempotency key, either in its idempotency_key field or in the
+	// idempotency-key metadata.
+	NonIdempotent []string
+}
+
+func (o RetryOptions) withDefaults() RetryOptions {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 3
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = 100 * time.Millisecond
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 5 * time.Second
+	}
+	if o.Multiplier < 1 {
+		o.Multiplier = 2
+	}
+	if o.Jitter <= 0 {
+		o.Jitter = 0.2
+	}
+	return o
+}
+
+// UnaryClientRetry retries unary calls failing with a retryable code, with
+// exponential backoff. When the server sends a RetryInfo, its retry delay
+// is used instead of the backoff. Calls are not retried when the next
+// attempt would start after the caller's deadline.
+func UnaryClientRetry(opts RetryOptions) grpc.UnaryClientInterceptor {
+	opts = opts.withDefaults()
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		retryable := opts.RetryableCodes[method]
+		if retryable == nil {
+			retryable = DefaultRetryableCodes
+		}
+		maxAttempts := opts.MaxAttempts
+		if slices.Contains(opts.NonIdempotent, method) && !hasIdempotencyKey(ctx, req) {
+			maxAttempts = 1
+		}
+
+		backoff := opts.InitialBackoff
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, callOpts...)
+			if err == nil || attempt >= maxAttempts {
+				return err
+			}
+			st := status.Convert(err)
+			if !slices.Contains(retryable, st.Code()) {
+				return err
+			}
+
+			delay := jitter(backoff, opts.Jitter)
+			if pushback, ok := rpcerr.RetryDelay(err); ok {
+				delay = pushback
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+				return err
+			}
+			slog.DebugContext(ctx, "retrying call",
+				slog.String("grpc.method", method),
+				slog.String("grpc.code", st.Code().String()),
+				slog.Int("attempt", attempt),
+				slog.Duration("delay", delay),
+			)
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+			backoff = min(time.Duration(float64(backoff)*opts.Multiplier), opts.MaxBackoff)
+		}
+	}
+}
+
+func jitter(d time.Duration, fraction float64) time.Duration {
+	return time.Duration(float64(d) * (1 + fraction*(2*rand.Float64()-1)))
+}
+
+// idempotencyKeyField is the request field carrying the idempotency key.
+const idempotencyKeyField = "idempotency_key"
+
+func hasIdempotencyKey(ctx context.Context, req any) bool {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(idempotency.MetadataKey)) > 0 {
+		return true
+	}
+	m, ok := req.(proto.Message)
+	if !ok {
+		return false
+	}
+	fd := m.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(idempotencyKeyField))
+	return fd != nil && m.ProtoReflect().Has(fd)
+}
@@ -0,0 +1,158 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ch3/svc/pkg/idempotency"
+	pb "ch3/svc/protos/ordermgt/v1"
+
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const testMethod = "/ecommerce.v1.OrderManagementService/GetOrder"
+
+// newTestConn returns a connection the interceptors can read the target of.
+// It never connects, the tests faking the invokers.
+func newTestConn(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	cc, err := grpc.NewClient("passthrough:///test", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return cc
+}
+
+// failingInvoker fails the first failures calls with err, then succeeds.
+// It counts the calls in calls.
+func failingInvoker(calls *int, failures int, err error) grpc.UnaryInvoker {
+	return func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		*calls++
+		if *calls <= failures {
+			return err
+		}
+		return nil
+	}
+}
+
+var fastRetries = RetryOptions{InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func TestRetryRetriesUntilSuccess(t *testing.T) {
+	var calls int
+	err := UnaryClientRetry(fastRetries)(context.Background(), testMethod, nil, nil, newTestConn(t),
+		failingInvoker(&calls, 2, status.Error(codes.Unavailable, "down")))
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("got %d attempts, want 3", calls)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	opts := fastRetries
+	opts.MaxAttempts = 4
+	var calls int
+	err := UnaryClientRetry(opts)(context.Background(), testMethod, nil, nil, newTestConn(t),
+		failingInvoker(&calls, 10, status.Error(codes.Unavailable, "down")))
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("error = %v, want Unavailable", err)
+	}
+	if calls != 4 {
+		t.Errorf("got %d attempts, want 4", calls)
+	}
+}
+
+func TestRetryOnlyRetriesRetryableCodes(t *testing.T) {
+	opts := fastRetries
+	opts.RetryableCodes = map[string][]codes.Code{testMethod: {codes.ResourceExhausted}}
+	interceptor := UnaryClientRetry(opts)
+	cc := newTestConn(t)
+
+	var calls int
+	interceptor(context.Background(), testMethod, nil, nil, cc, failingInvoker(&calls, 10, status.Error(codes.Unavailable, "down")))
+	if calls != 1 {
+		t.Errorf("Unavailable not retryable for the method: got %d attempts, want 1", calls)
+	}
+	calls = 0
+	interceptor(context.Background(), testMethod, nil, nil, cc, failingInvoker(&calls, 1, status.Error(codes.ResourceExhausted, "busy")))
+	if calls != 2 {
+		t.Errorf("ResourceExhausted retryable for the method: got %d attempts, want 2", calls)
+	}
+}
+
+func TestRetryNonIdempotentMethods(t *testing.T) {
+	const method = "/ecommerce.v1.OrderManagementService/CreateOrder"
+	opts := fastRetries
+	opts.NonIdempotent = []string{method}
+	interceptor := UnaryClientRetry(opts)
+	cc := newTestConn(t)
+	unavailable := status.Error(codes.Unavailable, "down")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *pb.CreateOrderRequest
+		want int
+	}{
+		{name: "without key", ctx: context.Background(), req: &pb.CreateOrderRequest{}, want: 1},
+		{name: "key field", ctx: context.Background(), req: &pb.CreateOrderRequest{IdempotencyKey: "key"}, want: 2},
+		{
+			name: "key metadata",
+			ctx:  metadata.AppendToOutgoingContext(context.Background(), idempotency.MetadataKey, "key"),
+			req:  &pb.CreateOrderRequest{},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			interceptor(tt.ctx, method, tt.req, nil, cc, failingInvoker(&calls, 1, unavailable))
+			if calls != tt.want {
+				t.Errorf("got %d attempts, want %d", calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryHonorsRetryInfo(t *testing.T) {
+	const pushback = 50 * time.Millisecond
+	st, err := status.New(codes.Unavailable, "busy").WithDetails(&epb.RetryInfo{RetryDelay: durationpb.New(pushback)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var calls int
+	start := time.Now()
+	if err := UnaryClientRetry(fastRetries)(context.Background(), testMethod, nil, nil, newTestConn(t), failingInvoker(&calls, 1, st.Err())); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < pushback {
+		t.Errorf("retried after %v, before the %v RetryInfo delay", elapsed, pushback)
+	}
+}
+
+func TestRetryRespectsDeadline(t *testing.T) {
+	opts := RetryOptions{InitialBackoff: time.Second}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var calls int
+	start := time.Now()
+	err := UnaryClientRetry(opts)(ctx, testMethod, nil, nil, newTestConn(t), failingInvoker(&calls, 10, status.Error(codes.Unavailable, "down")))
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("error = %v, want the Unavailable of the attempt", err)
+	}
+	if calls != 1 {
+		t.Errorf("got %d attempts, want 1: the backoff ends after the deadline", calls)
+	}
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("gave up after %v, want right away", elapsed)
+	}
+}
@@ -0,0 +1,163 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"ch3/svc/pkg/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const tracerName = "ch3/svc/pkg/client/interceptors"
+
+// TracingOptions configures the tracing interceptors.
+type TracingOptions struct {
+	// TracerProvider creates the spans. otel.GetTracerProvider() is used
+	// when nil.
+	TracerProvider trace.TracerProvider
+	// Propagator injects the trace context into the request metadata.
+	// otel.GetTextMapPropagator() is used when nil.
+	Propagator propagation.TextMapPropagator
+}
+
+func (o TracingOptions) tracer() trace.Tracer {
+	tp := o.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(tracerName)
+}
+
+func (o TracingOptions) propagator() propagation.TextMapPropagator {
+	if o.Propagator == nil {
+		return otel.GetTextMapPropagator()
+	}
+	return o.Propagator
+}
+
+// UnaryClientTracing starts a client span for every unary RPC and sends its
+// trace context to the server in the traceparent metadata.
+func UnaryClientTracing(opts TracingOptions) grpc.UnaryClientInterceptor {
+	tracer, propagator := opts.tracer(), opts.propagator()
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		ctx, span := startSpan(ctx, tracer, propagator, cc.Target(), method)
+		defer span.End()
+		addMessageEvent(span, semconv.RPCMessageTypeSent, 1, req)
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+		if err == nil {
+			addMessageEvent(span, semconv.RPCMessageTypeReceived, 1, reply)
+		}
+		endSpan(span, err)
+		return err
+	}
+}
+
+// StreamClientTracing starts a client span for every streaming RPC, with an
+// event for every message sent and received. The span ends with the stream,
+// or when its context is done for streams abandoned by the caller.
+func StreamClientTracing(opts TracingOptions) grpc.StreamClientInterceptor {
+	tracer, propagator := opts.tracer(), opts.propagator()
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := startSpan(ctx, tracer, propagator, cc.Target(), method)
+		s, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			endSpan(span, err)
+			span.End()
+			return nil, err
+		}
+		stream := &tracingStream{ClientStream: s, desc: desc, span: span, done: make(chan struct{})}
+		go func() {
+			select {
+			case <-stream.done:
+			case <-s.Context().Done():
+				stream.finish(s.Context().Err())
+			}
+		}()
+		return stream, nil
+	}
+}
+
+// tracingStream records the messages of a client stream as span events and
+// ends the span once the status of the stream is known.
+type tracingStream struct {
+	grpc.ClientStream
+	desc           *grpc.StreamDesc
+	span           trace.Span
+	sent, received atomic.Int64
+	done           chan struct{}
+	finishOnce     sync.Once
+}
+
+func (s *tracingStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		addMessageEvent(s.span, semconv.RPCMessageTypeSent, s.sent.Add(1), m)
+	}
+	return err
+}
+
+func (s *tracingStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		addMessageEvent(s.span, semconv.RPCMessageTypeReceived, s.received.Add(1), m)
+		if !s.desc.ServerStreams {
+			// The single response ends the call.
+			s.finish(nil)
+		}
+	case errors.Is(err, io.EOF):
+		s.finish(nil)
+	default:
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *tracingStream) finish(err error) {
+	s.finishOnce.Do(func() {
+		endSpan(s.span, err)
+		s.span.End()
+		close(s.done)
+	})
+}
+
+func startSpan(ctx context.Context, tracer trace.Tracer, propagator propagation.TextMapPropagator, target, fullMethod string) (context.Context, trace.Span) {
+	service, method := splitMethod(fullMethod)
+	ctx, span := tracer.Start(ctx, fullMethod[1:],
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(method),
+			semconv.ServerAddress(target),
+		),
+	)
+	return tracing.Inject(ctx, propagator), span
+}
+
+func endSpan(span trace.Span, err error) {
+	st := status.Convert(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+	if err != nil {
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+}
+
+func addMessageEvent(span trace.Span, typ attribute.KeyValue, id int64, m any) {
+	span.AddEvent("message", trace.WithAttributes(
+		typ,
+		semconv.RPCMessageIDKey.Int64(id),
+		semconv.RPCMessageUncompressedSizeKey.Int(messageSize(m)),
+	))
+}
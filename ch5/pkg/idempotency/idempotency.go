@@ -0,0 +1,218 @@
+// Package idempotency remembers the responses to requests sent with an
+// idempotency key, so that a retried request gets the original response
+// instead of being executed again.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"ch3/svc/pkg/auth"
+	"ch3/svc/pkg/rpcerr"
+
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// MetadataKey is the request metadata carrying the idempotency key.
+	MetadataKey = "idempotency-key"
+
+	// requestFieldName is the request field carrying the idempotency key.
+	// It is left out of the request fingerprint.
+	requestFieldName = "idempotency_key"
+
+	maxKeyLength = 256
+)
+
+// Key returns the idempotency key of the request, sent either in the
+// requestKey field or in the MetadataKey metadata. An empty key means the
+// request isn't idempotent.
+func Key(ctx context.Context, requestKey string) (string, error) {
+	key := requestKey
+	if values := metadata.ValueFromIncomingContext(ctx, MetadataKey); len(values) > 0 {
+		mdKey := values[len(values)-1]
+		if key != "" && key != mdKey {
+			return "", rpcerr.InvalidArgument("idempotency key differs between the request and its metadata", &epb.BadRequest_FieldViolation{
+				Field:       requestFieldName,
+				Description: fmt.Sprintf("Idempotency key must match the %s metadata", MetadataKey),
+			})
+		}
+		key = mdKey
+	}
+	if len(key) > maxKeyLength {
+		return "", rpcerr.InvalidArgument("invalid idempotency key", &epb.BadRequest_FieldViolation{
+			Field:       requestFieldName,
+			Description: fmt.Sprintf("Idempotency key is longer than %d characters", maxKeyLength),
+		})
+	}
+	return key, nil
+}
+
+// Cache holds the responses to idempotent requests for a TTL.
+// It is safe for concurrent use.
+type Cache struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	entries   map[cacheKey]*entry
+	lastSweep time.Time
+}
+
+// cacheKey scopes the idempotency keys to their caller, so that a caller
+// can't get the responses of another one by reusing its keys.
+type cacheKey struct {
+	caller, key string
+}
+
+type entry struct {
+	fingerprint [sha256.Size]byte
+	// done is closed once the first request with the key completes
+	done    chan struct{}
+	resp    proto.Message
+	expires time.Time
+}
+
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{ttl: ttl, entries: make(map[cacheKey]*entry), lastSweep: time.Now()}
+}
+
+// Do runs fn for the first request with the given key and returns its
+// response to the later requests of the same caller with the same key,
+// until the response expires. Requests reusing a key with a different payload fail with
+// Aborted. Concurrent requests with the same key wait for the first one.
+// Failed requests aren't remembered, so that they can be retried.
+func Do[T proto.Message](ctx context.Context, c *Cache, key string, req proto.Message, fn func() (T, error)) (T, error) {
+	if key == "" {
+		return fn()
+	}
+	fp, err := fingerprint(req)
+	if err != nil {
+		var zero T
+		return zero, rpcerr.New(codes.Internal, rpcerr.Internal, "failed to fingerprint request")
+	}
+	ck := cacheKey{caller: caller(ctx), key: key}
+	for {
+		e, first := c.acquire(ck, fp)
+		if first {
+			return run(c, ck, e, fn)
+		}
+		if e.fingerprint != fp {
+			var zero T
+			return zero, rpcerr.New(codes.Aborted, rpcerr.IdempotencyKeyReused, "idempotency key was already used for a different request")
+		}
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			var zero T
+			return zero, status.FromContextError(ctx.Err()).Err()
+		}
+		if e.resp == nil {
+			// The first request failed, run this one instead.
+			continue
+		}
+		return proto.Clone(e.resp).(T), nil
+	}
+}
+
+// run calls fn for the first request with key and completes its entry. A
+// panicking fn fails the entry before the panic goes on, so that the
+// requests waiting on it don't hang.
+func run[T proto.Message](c *Cache, key cacheKey, e *entry, fn func() (T, error)) (resp T, err error) {
+	completed := false
+	defer func() {
+		if !completed {
+			c.complete(key, e, nil, errors.New("request panicked"))
+		}
+	}()
+	resp, err = fn()
+	c.complete(key, e, resp, err)
+	completed = true
+	return resp, err
+}
+
+// acquire returns the live entry for key, or creates one reporting that the
+// caller is the first request with the key.
+func (c *Cache) acquire(key cacheKey, fp [sha256.Size]byte) (*entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if now.Sub(c.lastSweep) > c.ttl {
+		c.sweep(now)
+	}
+	if e, ok := c.entries[key]; ok && !e.expired(now) {
+		return e, false
+	}
+	e := &entry{fingerprint: fp, done: make(chan struct{})}
+	c.entries[key] = e
+	return e, true
+}
+
+func (c *Cache) complete(key cacheKey, e *entry, resp proto.Message, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		delete(c.entries, key)
+	} else {
+		e.resp = resp
+		e.expires = time.Now().Add(c.ttl)
+	}
+	close(e.done)
+}
+
+// sweep drops the expired entries. c.mu must be held.
+func (c *Cache) sweep(now time.Time) {
+	for key, e := range c.entries {
+		if e.expired(now) {
+			delete(c.entries, key)
+		}
+	}
+	c.lastSweep = now
+}
+
+func (e *entry) expired(now time.Time) bool {
+	select {
+	case <-e.done:
+		return e.resp != nil && now.After(e.expires)
+	default:
+		return false
+	}
+}
+
+// caller identifies the caller of the request by its authenticated subject,
+// or by its IP address when the server doesn't authenticate callers.
+func caller(ctx context.Context) string {
+	if id, ok := auth.FromContext(ctx); ok {
+		return "subject:" + id.Subject
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "ip:" + host
+	}
+	return ""
+}
+
+// fingerprint hashes the request payload, leaving out its idempotency key.
+func fingerprint(req proto.Message) ([sha256.Size]byte, error) {
+	req = proto.Clone(req)
+	if fd := req.ProtoReflect().Descriptor().Fields().ByName(requestFieldName); fd != nil {
+		req.ProtoReflect().Clear(fd)
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(b), nil
+}
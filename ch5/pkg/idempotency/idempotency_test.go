@@ -0,0 +1,231 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ch3/svc/pkg/auth"
+	pb "ch3/svc/protos/ordermgt/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestDoReplaysResponse(t *testing.T) {
+	c := NewCache(time.Minute)
+	ctx := context.Background()
+	var calls int
+	create := func() (*pb.CreateOrderResponse, error) {
+		calls++
+		return &pb.CreateOrderResponse{Id: "order-1", Price: 10}, nil
+	}
+
+	first, err := Do(ctx, c, "key", &pb.CreateOrderRequest{Price: 10, IdempotencyKey: "key"}, create)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	// The key itself isn't part of the fingerprint, it may move to metadata.
+	replay, err := Do(ctx, c, "key", &pb.CreateOrderRequest{Price: 10}, create)
+	if err != nil {
+		t.Fatalf("Do replay: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+	if replay.Id != first.Id {
+		t.Errorf("replayed order %s, want %s", replay.Id, first.Id)
+	}
+	if replay == first {
+		t.Error("replay returned the cached message instead of a copy")
+	}
+}
+
+func TestDoRejectsKeyReuse(t *testing.T) {
+	c := NewCache(time.Minute)
+	ctx := context.Background()
+	create := func() (*pb.CreateOrderResponse, error) { return &pb.CreateOrderResponse{Id: "order-1"}, nil }
+	if _, err := Do(ctx, c, "key", &pb.CreateOrderRequest{Price: 10}, create); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	_, err := Do(ctx, c, "key", &pb.CreateOrderRequest{Price: 20}, create)
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("Do with a different request = %v, want Aborted", err)
+	}
+}
+
+func TestDoScopesKeysToCaller(t *testing.T) {
+	c := NewCache(time.Minute)
+	alice := auth.NewContext(context.Background(), &auth.Identity{Subject: "alice"})
+	bob := auth.NewContext(context.Background(), &auth.Identity{Subject: "bob"})
+	var calls int
+	create := func(id string) func() (*pb.CreateOrderResponse, error) {
+		return func() (*pb.CreateOrderResponse, error) {
+			calls++
+			return &pb.CreateOrderResponse{Id: id}, nil
+		}
+	}
+
+	if _, err := Do(alice, c, "key", &pb.CreateOrderRequest{Price: 10}, create("order-1")); err != nil {
+		t.Fatalf("Do as alice: %v", err)
+	}
+	// Bob reusing the key of alice gets his own order, not the one of alice.
+	resp, err := Do(bob, c, "key", &pb.CreateOrderRequest{Price: 10}, create("order-2"))
+	if err != nil || resp.Id != "order-2" {
+		t.Errorf("Do as bob = %v, %v, want order-2", resp, err)
+	}
+	// Nor does he learn the key is used with a different payload.
+	if _, err := Do(bob, c, "other", &pb.CreateOrderRequest{Price: 10}, create("order-3")); err != nil {
+		t.Fatalf("Do as bob: %v", err)
+	}
+	if _, err := Do(alice, c, "other", &pb.CreateOrderRequest{Price: 20}, create("order-4")); err != nil {
+		t.Errorf("Do as alice with a key bob used = %v, want success", err)
+	}
+	if calls != 4 {
+		t.Errorf("fn called %d times, want 4", calls)
+	}
+
+	// Each caller still gets its own responses replayed.
+	resp, err = Do(alice, c, "key", &pb.CreateOrderRequest{Price: 10}, create("order-5"))
+	if err != nil || resp.Id != "order-1" {
+		t.Errorf("replay as alice = %v, %v, want order-1", resp, err)
+	}
+}
+
+func TestDoForgetsFailures(t *testing.T) {
+	c := NewCache(time.Minute)
+	ctx := context.Background()
+	req := &pb.CreateOrderRequest{Price: 10}
+	_, err := Do(ctx, c, "key", req, func() (*pb.CreateOrderResponse, error) {
+		return nil, status.Error(codes.Unavailable, "down")
+	})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("Do error = %v, want Unavailable", err)
+	}
+	resp, err := Do(ctx, c, "key", req, func() (*pb.CreateOrderResponse, error) {
+		return &pb.CreateOrderResponse{Id: "order-1"}, nil
+	})
+	if err != nil || resp.Id != "order-1" {
+		t.Errorf("retry after failure = %v, %v, want order-1", resp, err)
+	}
+}
+
+func TestDoExpiresResponses(t *testing.T) {
+	c := NewCache(10 * time.Millisecond)
+	ctx := context.Background()
+	var calls int
+	create := func() (*pb.CreateOrderResponse, error) {
+		calls++
+		return &pb.CreateOrderResponse{}, nil
+	}
+	Do(ctx, c, "key", &pb.CreateOrderRequest{}, create)
+	time.Sleep(20 * time.Millisecond)
+	Do(ctx, c, "key", &pb.CreateOrderRequest{}, create)
+	if calls != 2 {
+		t.Errorf("fn called %d times, want 2 once the response expired", calls)
+	}
+}
+
+func TestDoRunsConcurrentRequestsOnce(t *testing.T) {
+	c := NewCache(time.Minute)
+	ctx := context.Background()
+	var calls atomic.Int32
+	release := make(chan struct{})
+	create := func() (*pb.CreateOrderResponse, error) {
+		calls.Add(1)
+		<-release
+		return &pb.CreateOrderResponse{Id: "order-1"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := Do(ctx, c, "key", &pb.CreateOrderRequest{}, create)
+			if err != nil || resp.Id != "order-1" {
+				t.Errorf("Do = %v, %v, want order-1", resp, err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("fn called %d times, want 1", calls.Load())
+	}
+}
+
+func TestDoFailsEntryOfPanickingRequest(t *testing.T) {
+	c := NewCache(time.Minute)
+	ctx := context.Background()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Do swallowed the panic")
+			}
+		}()
+		Do(ctx, c, "key", &pb.CreateOrderRequest{}, func() (*pb.CreateOrderResponse, error) {
+			panic("boom")
+		})
+	}()
+
+	// The retry runs instead of waiting for the panicked request.
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	resp, err := Do(ctx, c, "key", &pb.CreateOrderRequest{}, func() (*pb.CreateOrderResponse, error) {
+		return &pb.CreateOrderResponse{Id: "order-1"}, nil
+	})
+	if err != nil || resp.Id != "order-1" {
+		t.Errorf("retry after panic = %v, %v, want order-1", resp, err)
+	}
+}
+
+func TestDoWithoutKey(t *testing.T) {
+	c := NewCache(time.Minute)
+	var calls int
+	for range 2 {
+		Do(context.Background(), c, "", &pb.CreateOrderRequest{}, func() (*pb.CreateOrderResponse, error) {
+			calls++
+			return &pb.CreateOrderResponse{}, nil
+		})
+	}
+	if calls != 2 {
+		t.Errorf("fn called %d times without a key, want 2", calls)
+	}
+}
+
+func TestKey(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "md-key"))
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		requestKey string
+		want       string
+		wantErr    bool
+	}{
+		{name: "none", ctx: context.Background()},
+		{name: "field", ctx: context.Background(), requestKey: "field-key", want: "field-key"},
+		{name: "metadata", ctx: ctx, want: "md-key"},
+		{name: "both", ctx: ctx, requestKey: "md-key", want: "md-key"},
+		{name: "mismatch", ctx: ctx, requestKey: "field-key", wantErr: true},
+		{name: "too long", ctx: context.Background(), requestKey: string(make([]byte, maxKeyLength+1)), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := Key(tt.ctx, tt.requestKey)
+			if tt.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("Key error = %v, want InvalidArgument", err)
+				}
+				return
+			}
+			if err != nil || key != tt.want {
+				t.Errorf("Key = %q, %v, want %q", key, err, tt.want)
+			}
+		})
+	}
+}
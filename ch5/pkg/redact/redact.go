@@ -0,0 +1,105 @@
+// Package redact masks the fields marked with the (ecommerce.v1.sensitive)
+// option so that messages can be logged without leaking their content.
+package redact
+
+import (
+	"fmt"
+	"log/slog"
+
+	pb "ch3/svc/protos/ordermgt/v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Mask replaces the value of sensitive string fields. Sensitive fields of
+// other types are cleared.
+const Mask = "[REDACTED]"
+
+// Message returns a copy of m with its sensitive fields masked, including the
+// ones of nested messages. Messages packed in google.protobuf.Any fields are
+// left as they are.
+func Message(m proto.Message) proto.Message {
+	if m == nil {
+		return nil
+	}
+	m = proto.Clone(m)
+	redact(m.ProtoReflect())
+	return m
+}
+
+// String renders m as JSON with its sensitive fields masked. Values other
+// than messages are formatted with %v.
+func String(v any) string {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	b, err := protojson.Marshal(Message(m))
+	if err != nil {
+		return fmt.Sprintf("<%T: %v>", m, err)
+	}
+	return string(b)
+}
+
+// Value renders v as String does, lazily, when it's logged with log/slog.
+type Value struct {
+	V any
+}
+
+func (v Value) LogValue() slog.Value {
+	return slog.StringValue(String(v.V))
+}
+
+func redact(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case isSensitive(fd):
+			mask(m, fd, v)
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					redact(v.Message())
+					return true
+				})
+			}
+		case fd.Message() != nil && fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				redact(list.Get(i).Message())
+			}
+		case fd.Message() != nil:
+			redact(v.Message())
+		}
+		return true
+	})
+}
+
+func mask(m protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	masked := protoreflect.ValueOfString(Mask)
+	switch {
+	case fd.IsMap() && fd.MapValue().Kind() == protoreflect.StringKind:
+		v.Map().Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			v.Map().Set(k, masked)
+			return true
+		})
+	case fd.IsList() && fd.Kind() == protoreflect.StringKind:
+		for i := 0; i < v.List().Len(); i++ {
+			v.List().Set(i, masked)
+		}
+	case !fd.IsMap() && !fd.IsList() && fd.Kind() == protoreflect.StringKind:
+		m.Set(fd, masked)
+	default:
+		m.Clear(fd)
+	}
+}
+
+func isSensitive(fd protoreflect.FieldDescriptor) bool {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil {
+		return false
+	}
+	return proto.GetExtension(opts, pb.E_Sensitive).(bool)
+}
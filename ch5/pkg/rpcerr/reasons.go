@@ -0,0 +1,42 @@
+package rpcerr
+
+// Reason identifies the cause of an error in the ErrorInfo domain. Reasons
+// are UPPER_SNAKE_CASE and never change once published, so that callers can
+// rely on them.
+type Reason string
+
+// Reasons of the errors of the ecommerce services.
+const (
+	// InvalidRequest errors carry a BadRequest listing the invalid fields.
+	InvalidRequest Reason = "INVALID_REQUEST"
+	// Internal errors are failures of the server, not of the request.
+	Internal Reason = "INTERNAL"
+	// Unauthenticated calls lack a valid bearer token.
+	Unauthenticated Reason = "UNAUTHENTICATED"
+	// MissingScopes errors list the scopes the caller lacks in the
+	// "scopes" metadata.
+	MissingScopes Reason = "MISSING_SCOPES"
+	// RateLimited calls went over their rate limit and carry a RetryInfo.
+	RateLimited Reason = "RATE_LIMITED"
+	// TooManyStreams calls went over the cap of streams in flight.
+	TooManyStreams Reason = "TOO_MANY_STREAMS"
+	// IdempotencyKeyReused requests reused an idempotency key sent before
+	// with a different request.
+	IdempotencyKeyReused Reason = "IDEMPOTENCY_KEY_REUSED"
+
+	OrderNotFound Reason = "ORDER_NOT_FOUND"
+	// InvalidOrderStatus errors carry a PreconditionFailure explaining why
+	// the order can't take the requested status.
+	InvalidOrderStatus Reason = "INVALID_ORDER_STATUS"
+	// OrdersNotCreated orders were valid but not created because other
+	// orders of their all-or-nothing CreateOrders stream were invalid.
+	OrdersNotCreated Reason = "ORDERS_NOT_CREATED"
+	// RevisionOutOfRange watches started from a revision no longer kept.
+	RevisionOutOfRange Reason = "REVISION_OUT_OF_RANGE"
+	// WatcherDropped watches lagged too far behind the order changes.
+	WatcherDropped Reason = "WATCHER_DROPPED"
+	// ProductServiceUnavailable errors are transient failures of the
+	// ProductInfoService, worth retrying.
+	ProductServiceUnavailable Reason = "PRODUCT_SERVICE_UNAVAILABLE"
+	ProductServiceFailure     Reason = "PRODUCT_SERVICE_FAILURE"
+)
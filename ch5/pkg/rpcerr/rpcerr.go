@@ -0,0 +1,117 @@
+// Package rpcerr builds the gRPC errors of the services in the rich error
+// model, annotates them without losing their status and extracts their
+// details for callers.
+package rpcerr
+
+import (
+	"time"
+
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Wrap annotates err with msg. Unlike fmt.Errorf, the returned error keeps
+// the code and details of err for status.Code, status.FromError and the
+// helpers of this package, with its message prefixed by msg. errors.Is and
+// errors.As see the wrapped error through Unwrap.
+func Wrap(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return &wrappedError{msg: msg, err: err}
+}
+
+type wrappedError struct {
+	msg string
+	err error
+}
+
+func (e *wrappedError) Error() string {
+	return e.msg + ": " + e.err.Error()
+}
+
+func (e *wrappedError) Unwrap() error {
+	return e.err
+}
+
+func (e *wrappedError) GRPCStatus() *status.Status {
+	p := status.Convert(e.err).Proto()
+	p.Message = e.msg + ": " + p.Message
+	return status.FromProto(p)
+}
+
+// Details returns the details of type T, such as *errdetails.BadRequest,
+// carried by the status of err.
+func Details[T proto.Message](err error) []T {
+	if err == nil {
+		return nil
+	}
+	var details []T
+	for _, d := range status.Convert(err).Details() {
+		// Details that can't be decoded are returned as errors.
+		if detail, ok := d.(T); ok {
+			details = append(details, detail)
+		}
+	}
+	return details
+}
+
+// Detail returns the first detail of type T carried by the status of err.
+func Detail[T proto.Message](err error) (T, bool) {
+	details := Details[T](err)
+	if len(details) == 0 {
+		var zero T
+		return zero, false
+	}
+	return details[0], true
+}
+
+// FieldViolations returns the invalid request fields reported by err, both
+// in BadRequest details and as bare field violations.
+func FieldViolations(err error) []*epb.BadRequest_FieldViolation {
+	var violations []*epb.BadRequest_FieldViolation
+	for _, badRequest := range Details[*epb.BadRequest](err) {
+		violations = append(violations, badRequest.GetFieldViolations()...)
+	}
+	return append(violations, Details[*epb.BadRequest_FieldViolation](err)...)
+}
+
+// PreconditionViolations returns the failed preconditions reported by err.
+func PreconditionViolations(err error) []*epb.PreconditionFailure_Violation {
+	var violations []*epb.PreconditionFailure_Violation
+	for _, failure := range Details[*epb.PreconditionFailure](err) {
+		violations = append(violations, failure.GetViolations()...)
+	}
+	return violations
+}
+
+// QuotaViolations returns the exceeded quotas reported by err.
+func QuotaViolations(err error) []*epb.QuotaFailure_Violation {
+	var violations []*epb.QuotaFailure_Violation
+	for _, failure := range Details[*epb.QuotaFailure](err) {
+		violations = append(violations, failure.GetViolations()...)
+	}
+	return violations
+}
+
+// RetryDelay returns the delay the server asked to wait before retrying in
+// a RetryInfo.
+func RetryDelay(err error) (time.Duration, bool) {
+	for _, info := range Details[*epb.RetryInfo](err) {
+		if info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// ErrorInfo returns the reason of err, with its domain and metadata.
+func ErrorInfo(err error) (*epb.ErrorInfo, bool) {
+	return Detail[*epb.ErrorInfo](err)
+}
+
+// DebugInfo returns the debugging information the server sent with err.
+func DebugInfo(err error) (*epb.DebugInfo, bool) {
+	return Detail[*epb.DebugInfo](err)
+}
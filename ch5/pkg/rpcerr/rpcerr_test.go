@@ -0,0 +1,112 @@
+package rpcerr
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestWrapKeepsStatus(t *testing.T) {
+	err := NotFound(OrderNotFound, "order", "order-1")
+	wrapped := Wrap(err, "failed to get order")
+
+	if code := status.Code(wrapped); code != codes.NotFound {
+		t.Errorf("status.Code = %s, want NotFound", code)
+	}
+	st, ok := status.FromError(wrapped)
+	if !ok {
+		t.Fatal("status.FromError doesn't see the status of the wrapped error")
+	}
+	if want := `failed to get order: order "order-1" not found`; st.Message() != want {
+		t.Errorf("status message = %q, want %q", st.Message(), want)
+	}
+	if len(st.Details()) != len(status.Convert(err).Details()) {
+		t.Errorf("got %d details, want the %d of the wrapped error", len(st.Details()), len(status.Convert(err).Details()))
+	}
+	if !errors.Is(wrapped, err) {
+		t.Error("errors.Is doesn't see the wrapped error")
+	}
+	if want := "failed to get order: " + err.Error(); wrapped.Error() != want {
+		t.Errorf("Error = %q, want %q", wrapped.Error(), want)
+	}
+}
+
+func TestWrapTwice(t *testing.T) {
+	err := status.Error(codes.Unavailable, "connection refused")
+	wrapped := Wrap(Wrap(err, "GetProduct"), "failed to resolve items")
+	st, ok := status.FromError(wrapped)
+	if !ok || st.Code() != codes.Unavailable {
+		t.Fatalf("status = %v, %t, want Unavailable", st, ok)
+	}
+	if want := "failed to resolve items: GetProduct: connection refused"; st.Message() != want {
+		t.Errorf("status message = %q, want %q", st.Message(), want)
+	}
+	if !errors.Is(wrapped, err) {
+		t.Error("errors.Is doesn't see through both wrappers")
+	}
+}
+
+func TestWrapNonStatusErrors(t *testing.T) {
+	if Wrap(nil, "context") != nil {
+		t.Error("Wrap(nil) isn't nil")
+	}
+	sentinel := errors.New("disk full")
+	wrapped := Wrap(sentinel, "failed to save order")
+	if code := status.Code(wrapped); code != codes.Unknown {
+		t.Errorf("status.Code = %s, want Unknown", code)
+	}
+	if !errors.Is(wrapped, sentinel) {
+		t.Error("errors.Is doesn't see the wrapped error")
+	}
+}
+
+func TestDetailsOfWrappedErrors(t *testing.T) {
+	invalid := Wrap(InvalidArgument("invalid order", &epb.BadRequest_FieldViolation{
+		Field:       "items[0].quantity",
+		Description: "Quantity must be positive",
+	}), "CreateOrder")
+	violations := FieldViolations(invalid)
+	if len(violations) != 1 || violations[0].Field != "items[0].quantity" {
+		t.Errorf("FieldViolations = %v, want items[0].quantity", violations)
+	}
+	if reason := ReasonOf(invalid); reason != InvalidRequest {
+		t.Errorf("ReasonOf = %q, want %q", reason, InvalidRequest)
+	}
+	info, ok := ErrorInfo(invalid)
+	if !ok || info.Domain != Domain || info.Reason != string(InvalidRequest) {
+		t.Errorf("ErrorInfo = %v, %t, want %s in %s", info, ok, InvalidRequest, Domain)
+	}
+	if _, ok := RetryDelay(invalid); ok {
+		t.Error("RetryDelay found in an error without RetryInfo")
+	}
+
+	limited := Wrap(New(codes.ResourceExhausted, RateLimited, "slow down",
+		&epb.RetryInfo{RetryDelay: durationpb.New(2 * time.Second)}), "GetOrder")
+	if delay, ok := RetryDelay(limited); !ok || delay != 2*time.Second {
+		t.Errorf("RetryDelay = %v, %t, want 2s", delay, ok)
+	}
+	if violations := FieldViolations(limited); len(violations) != 0 {
+		t.Errorf("FieldViolations = %v, want none", violations)
+	}
+}
+
+func TestReasonOfOtherDomains(t *testing.T) {
+	st, err := status.New(codes.Internal, "boom").WithDetails(&epb.ErrorInfo{Reason: string(Internal), Domain: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reason := ReasonOf(st.Err()); reason != "" {
+		t.Errorf("ReasonOf of another domain = %q, want none", reason)
+	}
+	if reason := ReasonOf(errors.New("boom")); reason != "" {
+		t.Errorf("ReasonOf of a non-status error = %q, want none", reason)
+	}
+	if details := Details[*epb.ErrorInfo](nil); details != nil {
+		t.Errorf("Details of nil = %v, want none", details)
+	}
+}
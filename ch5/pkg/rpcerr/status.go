@@ -0,0 +1,99 @@
+package rpcerr
+
+import (
+	"fmt"
+	"log/slog"
+
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+const (
+	// Domain is the domain of the ErrorInfo of every error, scoping its
+	// reason.
+	Domain = "ecommerce.v1"
+	// Locale is the locale of the LocalizedMessage of every error.
+	Locale = "en-US"
+)
+
+// Status describes a failed call in the google.rpc.Status error model. Its
+// ErrorInfo gives the stable reason callers switch on, while the message
+// is meant for humans and may change.
+type Status struct {
+	Code    codes.Code
+	Reason  Reason
+	Message string
+	// Metadata adds structured context to the ErrorInfo, such as the id of
+	// the resource involved.
+	Metadata map[string]string
+	// Details are sent after the ErrorInfo and LocalizedMessage.
+	Details []protoadapt.MessageV1
+}
+
+// Err returns the status as an error carrying an ErrorInfo and
+// a LocalizedMessage, followed by the other details. The details are
+// dropped if they can't be encoded.
+func (s Status) Err() error {
+	errorStatus := status.New(s.Code, s.Message)
+	details := append([]protoadapt.MessageV1{
+		&epb.ErrorInfo{Reason: string(s.Reason), Domain: Domain, Metadata: s.Metadata},
+		&epb.LocalizedMessage{Locale: Locale, Message: s.Message},
+	}, s.Details...)
+	ds, err := errorStatus.WithDetails(details...)
+	if err != nil {
+		slog.Error("error generating error details", slog.String("reason", string(s.Reason)), slog.String("error", err.Error()))
+		return errorStatus.Err()
+	}
+	return ds.Err()
+}
+
+// New returns an error with code, reason and message, carrying details.
+func New(code codes.Code, reason Reason, msg string, details ...protoadapt.MessageV1) error {
+	return Status{Code: code, Reason: reason, Message: msg, Details: details}.Err()
+}
+
+// Newf is New with a formatted message.
+func Newf(code codes.Code, reason Reason, format string, args ...any) error {
+	return New(code, reason, fmt.Sprintf(format, args...))
+}
+
+// InvalidArgument returns an InvalidArgument error with the InvalidRequest
+// reason, carrying a BadRequest with the violations.
+func InvalidArgument(msg string, violations ...*epb.BadRequest_FieldViolation) error {
+	return New(codes.InvalidArgument, InvalidRequest, msg, &epb.BadRequest{FieldViolations: violations})
+}
+
+// NotFound returns a NotFound error carrying a ResourceInfo identifying the
+// missing resource, whose name is also added to the ErrorInfo metadata.
+func NotFound(reason Reason, resourceType, resourceName string) error {
+	msg := fmt.Sprintf("%s %q not found", resourceType, resourceName)
+	return Status{
+		Code:     codes.NotFound,
+		Reason:   reason,
+		Message:  msg,
+		Metadata: map[string]string{"resource_type": resourceType, "resource_name": resourceName},
+		Details: []protoadapt.MessageV1{&epb.ResourceInfo{
+			ResourceType: resourceType,
+			ResourceName: resourceName,
+			Description:  "Resource does not exist.",
+		}},
+	}.Err()
+}
+
+// FailedPrecondition returns a FailedPrecondition error carrying
+// a PreconditionFailure with the violations.
+func FailedPrecondition(reason Reason, msg string, violations ...*epb.PreconditionFailure_Violation) error {
+	return New(codes.FailedPrecondition, reason, msg, &epb.PreconditionFailure{Violations: violations})
+}
+
+// ReasonOf returns the reason of the ErrorInfo carried by err, or an empty
+// reason when it has none or comes from another domain.
+func ReasonOf(err error) Reason {
+	info, ok := ErrorInfo(err)
+	if !ok || info.GetDomain() != Domain {
+		return ""
+	}
+	return Reason(info.GetReason())
+}
@@ -0,0 +1,183 @@
+// Package interceptors provides reusable gRPC server interceptors.
+package interceptors
+
+import (
+	"context"
+	"log/slog"
+	"path"
+	"sync/atomic"
+	"time"
+
+	"ch3/svc/pkg/redact"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// LoggingOptions configures the logging interceptors.
+type LoggingOptions struct {
+	// Logger receives the records. slog.Default() is used when nil.
+	Logger *slog.Logger
+	// Level maps the status code of a finished RPC to the level of its
+	// record. DefaultLevel is used when nil.
+	Level func(codes.Code) slog.Level
+	// Payloads adds the request and response messages, with their sensitive
+	// fields redacted, to the records of unary RPCs, and logs every message
+	// of streaming RPCs at Debug.
+	Payloads bool
+}
+
+// DefaultLevel logs successful RPCs at Info, the ones failed because of the
+// caller at Warn and the others at Error.
+func DefaultLevel(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
+		codes.PermissionDenied, codes.Unauthenticated, codes.ResourceExhausted,
+		codes.FailedPrecondition, codes.Aborted, codes.OutOfRange:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+func (o LoggingOptions) withDefaults() LoggingOptions {
+	if o.Logger == nil {
+		o.Logger = slog.Default()
+	}
+	if o.Level == nil {
+		o.Level = DefaultLevel
+	}
+	return o
+}
+
+// UnaryServerLogging logs one record per unary RPC once it completes.
+func UnaryServerLogging(opts LoggingOptions) grpc.UnaryServerInterceptor {
+	opts = opts.withDefaults()
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		attrs := []slog.Attr{
+			slog.Int("grpc.request.size", messageSize(req)),
+		}
+		if err == nil {
+			attrs = append(attrs, slog.Int("grpc.response.size", messageSize(resp)))
+		}
+		if opts.Payloads {
+			attrs = append(attrs, slog.Any("grpc.request.content", redact.Value{V: req}))
+			if err == nil {
+				attrs = append(attrs, slog.Any("grpc.response.content", redact.Value{V: resp}))
+			}
+		}
+		logCall(ctx, opts, "finished unary call", info.FullMethod, start, err, attrs...)
+		return resp, err
+	}
+}
+
+// StreamServerLogging logs one record per streaming RPC once it completes,
+// with the number and total size of the messages received and sent.
+func StreamServerLogging(opts LoggingOptions) grpc.StreamServerInterceptor {
+	opts = opts.withDefaults()
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		stream := &loggingStream{ServerStream: ss, opts: opts, fullMethod: info.FullMethod}
+		err := handler(srv, stream)
+		logCall(ss.Context(), opts, "finished streaming call", info.FullMethod, start, err,
+			slog.Bool("grpc.client_stream", info.IsClientStream),
+			slog.Bool("grpc.server_stream", info.IsServerStream),
+			slog.Int64("grpc.request.count", stream.received.Load()),
+			slog.Int64("grpc.request.size", stream.receivedBytes.Load()),
+			slog.Int64("grpc.response.count", stream.sent.Load()),
+			slog.Int64("grpc.response.size", stream.sentBytes.Load()),
+		)
+		return err
+	}
+}
+
+// loggingStream counts the messages going through a server stream.
+type loggingStream struct {
+	grpc.ServerStream
+	opts                    LoggingOptions
+	fullMethod              string
+	received, receivedBytes atomic.Int64
+	sent, sentBytes         atomic.Int64
+}
+
+func (s *loggingStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Add(1)
+		s.receivedBytes.Add(int64(messageSize(m)))
+		s.logMessage("received message", m)
+	}
+	return err
+}
+
+func (s *loggingStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Add(1)
+		s.sentBytes.Add(int64(messageSize(m)))
+		s.logMessage("sent message", m)
+	}
+	return err
+}
+
+func (s *loggingStream) logMessage(msg string, m any) {
+	if !s.opts.Payloads {
+		return
+	}
+	service, method := splitMethod(s.fullMethod)
+	s.opts.Logger.LogAttrs(s.Context(), slog.LevelDebug, msg,
+		slog.String("grpc.service", service),
+		slog.String("grpc.method", method),
+		slog.Any("grpc.content", redact.Value{V: m}),
+	)
+}
+
+func logCall(ctx context.Context, opts LoggingOptions, msg, fullMethod string, start time.Time, err error, extra ...slog.Attr) {
+	st := status.Convert(err)
+	level := opts.Level(st.Code())
+	if !opts.Logger.Enabled(ctx, level) {
+		return
+	}
+	service, method := splitMethod(fullMethod)
+	attrs := []slog.Attr{
+		slog.String("grpc.service", service),
+		slog.String("grpc.method", method),
+		slog.String("grpc.code", st.Code().String()),
+		slog.Float64("grpc.duration_ms", float64(time.Since(start))/float64(time.Millisecond)),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		attrs = append(attrs, slog.String("peer.address", p.Addr.String()))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("grpc.error", st.Message()))
+	}
+	attrs = append(attrs, extra...)
+	opts.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// splitMethod splits "/package.Service/Method" into its service and method.
+func splitMethod(fullMethod string) (string, string) {
+	service, method := path.Split(fullMethod)
+	if len(service) < 2 {
+		return "", method
+	}
+	return service[1 : len(service)-1], method
+}
+
+func messageSize(m any) int {
+	if pm, ok := m.(proto.Message); ok {
+		return proto.Size(pm)
+	}
+	return 0
+}
@@ -0,0 +1,172 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	unaryType        = "unary"
+	clientStreamType = "client_stream"
+	serverStreamType = "server_stream"
+	bidiStreamType   = "bidi_stream"
+)
+
+// ServerMetrics records Prometheus metrics of the RPCs handled by a server:
+// the number of started and handled RPCs by status code, their latency and
+// the number of stream messages received and sent.
+// It is a prometheus.Collector to register with a prometheus.Registerer.
+type ServerMetrics struct {
+	started     *prometheus.CounterVec
+	handled     *prometheus.CounterVec
+	handlingSec *prometheus.HistogramVec
+	msgReceived *prometheus.CounterVec
+	msgSent     *prometheus.CounterVec
+}
+
+func NewServerMetrics() *ServerMetrics {
+	labels := []string{"grpc_type", "grpc_service", "grpc_method"}
+	return &ServerMetrics{
+		started: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_started_total",
+			Help: "Total number of RPCs started on the server.",
+		}, labels),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "Total number of RPCs completed on the server, regardless of success or failure.",
+		}, append(labels, "grpc_code")),
+		handlingSec: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Histogram of response latency (seconds) of RPCs handled by the server.",
+			Buckets: prometheus.DefBuckets,
+		}, labels),
+		msgReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_msg_received_total",
+			Help: "Total number of RPC messages received on the server.",
+		}, labels),
+		msgSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_msg_sent_total",
+			Help: "Total number of RPC messages sent by the server.",
+		}, labels),
+	}
+}
+
+func (m *ServerMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.started.Describe(ch)
+	m.handled.Describe(ch)
+	m.handlingSec.Describe(ch)
+	m.msgReceived.Describe(ch)
+	m.msgSent.Describe(ch)
+}
+
+func (m *ServerMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.started.Collect(ch)
+	m.handled.Collect(ch)
+	m.handlingSec.Collect(ch)
+	m.msgReceived.Collect(ch)
+	m.msgSent.Collect(ch)
+}
+
+// InitializeMetrics exports zero-valued metrics for all the methods
+// registered on s, so that they show up before their first call.
+func (m *ServerMetrics) InitializeMetrics(s *grpc.Server) {
+	for service, info := range s.GetServiceInfo() {
+		for _, method := range info.Methods {
+			typ := rpcType(method.IsClientStream, method.IsServerStream)
+			m.started.WithLabelValues(typ, service, method.Name)
+			m.handlingSec.WithLabelValues(typ, service, method.Name)
+			m.msgReceived.WithLabelValues(typ, service, method.Name)
+			m.msgSent.WithLabelValues(typ, service, method.Name)
+		}
+	}
+}
+
+// UnaryServerInterceptor records the metrics of unary RPCs.
+func (m *ServerMetrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		r := m.start(unaryType, info.FullMethod)
+		r.msgReceived.Inc()
+		resp, err := handler(ctx, req)
+		if err == nil {
+			r.msgSent.Inc()
+		}
+		r.finish(err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records the metrics of streaming RPCs.
+func (m *ServerMetrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		r := m.start(rpcType(info.IsClientStream, info.IsServerStream), info.FullMethod)
+		err := handler(srv, &metricsStream{ServerStream: ss, r: r})
+		r.finish(err)
+		return err
+	}
+}
+
+// rpcMetrics holds the metrics of one RPC, resolved when it starts.
+type rpcMetrics struct {
+	m                    *ServerMetrics
+	labels               []string
+	start                time.Time
+	msgReceived, msgSent prometheus.Counter
+}
+
+func (m *ServerMetrics) start(typ, fullMethod string) *rpcMetrics {
+	service, method := splitMethod(fullMethod)
+	r := &rpcMetrics{
+		m:           m,
+		labels:      []string{typ, service, method},
+		start:       time.Now(),
+		msgReceived: m.msgReceived.WithLabelValues(typ, service, method),
+		msgSent:     m.msgSent.WithLabelValues(typ, service, method),
+	}
+	m.started.WithLabelValues(r.labels...).Inc()
+	return r
+}
+
+func (r *rpcMetrics) finish(err error) {
+	code := status.Code(err).String()
+	r.m.handled.WithLabelValues(append(r.labels, code)...).Inc()
+	r.m.handlingSec.WithLabelValues(r.labels...).Observe(time.Since(r.start).Seconds())
+}
+
+// metricsStream counts the messages going through a server stream.
+type metricsStream struct {
+	grpc.ServerStream
+	r *rpcMetrics
+}
+
+func (s *metricsStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.r.msgReceived.Inc()
+	}
+	return err
+}
+
+func (s *metricsStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.r.msgSent.Inc()
+	}
+	return err
+}
+
+func rpcType(clientStream, serverStream bool) string {
+	switch {
+	case clientStream && serverStream:
+		return bidiStreamType
+	case clientStream:
+		return clientStreamType
+	case serverStream:
+		return serverStreamType
+	default:
+		return unaryType
+	}
+}
@@ -0,0 +1,253 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"ch3/svc/pkg/auth"
+	"ch3/svc/pkg/rpcerr"
+
+	"golang.org/x/time/rate"
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// limiterIdleTTL is how long the token buckets of a caller are kept after
+// its last call.
+const limiterIdleTTL = 10 * time.Minute
+
+// RateLimit is a token bucket refilled with Rate tokens per second, up to
+// Burst tokens. A zero Rate means no limit.
+type RateLimit struct {
+	Rate  rate.Limit
+	Burst int
+}
+
+// RateLimitOptions configures the rate limiting interceptors.
+type RateLimitOptions struct {
+	// Default limits the calls of every caller to the methods without a
+	// limit of their own in Methods, by full method name. Each caller gets
+	// a token bucket per method.
+	Default RateLimit
+	Methods map[string]RateLimit
+	// StreamMessages limits the messages received on the streams of a
+	// caller, by full method name. Streams going over the limit are slowed
+	// down rather than failed.
+	StreamMessages map[string]RateLimit
+	// MaxStreams caps the streams in flight on the server, and
+	// MaxStreamsPerCaller the ones of each caller. Zero means no cap.
+	MaxStreams          int
+	MaxStreamsPerCaller int
+	// Exempt lists the full names of the methods never limited.
+	Exempt []string
+	// Caller identifies the caller of an RPC. Defaults to the subject of
+	// the authenticated identity, or the peer IP without one.
+	Caller func(context.Context) string
+}
+
+// RateLimiter rejects the calls going over their rate limits or stream caps
+// with ResourceExhausted, carrying a QuotaFailure and, when waiting helps,
+// a RetryInfo telling the caller when to retry.
+type RateLimiter struct {
+	opts RateLimitOptions
+
+	mu        sync.Mutex
+	limiters  map[limiterKey]*callerLimiter
+	lastSweep time.Time
+	// streams counts the streams in flight, callerStreams by caller
+	streams       int
+	callerStreams map[string]int
+}
+
+type limiterKey struct {
+	caller, method string
+	messages       bool
+}
+
+type callerLimiter struct {
+	*rate.Limiter
+	lastUsed time.Time
+}
+
+func NewRateLimiter(opts RateLimitOptions) *RateLimiter {
+	if opts.Caller == nil {
+		opts.Caller = callerFromContext
+	}
+	return &RateLimiter{
+		opts:          opts,
+		limiters:      make(map[limiterKey]*callerLimiter),
+		lastSweep:     time.Now(),
+		callerStreams: make(map[string]int),
+	}
+}
+
+// UnaryServerInterceptor rate limits unary RPCs.
+func (l *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if slices.Contains(l.opts.Exempt, info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if err := l.allow(l.opts.Caller(ctx), info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rate limits streaming RPCs and their messages,
+// and caps the streams in flight.
+func (l *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if slices.Contains(l.opts.Exempt, info.FullMethod) {
+			return handler(srv, ss)
+		}
+		caller := l.opts.Caller(ss.Context())
+		if err := l.allow(caller, info.FullMethod); err != nil {
+			return err
+		}
+		release, err := l.acquireStream(caller)
+		if err != nil {
+			return err
+		}
+		defer release()
+		if limit, ok := l.opts.StreamMessages[info.FullMethod]; ok && limit.Rate > 0 {
+			ss = &rateLimitedStream{
+				ServerStream: ss,
+				limiter:      l.limiter(limiterKey{caller, info.FullMethod, true}, limit),
+				caller:       caller,
+				method:       info.FullMethod,
+			}
+		}
+		return handler(srv, ss)
+	}
+}
+
+// rateLimitedStream waits for a token before receiving each message. The
+// stream fails when the token would come after its deadline.
+type rateLimitedStream struct {
+	grpc.ServerStream
+	limiter        *rate.Limiter
+	caller, method string
+}
+
+func (s *rateLimitedStream) RecvMsg(m any) error {
+	if err := s.limiter.Wait(s.Context()); err != nil {
+		if ctxErr := s.Context().Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+		// Wait gives up right away when the deadline comes first.
+		return resourceExhaustedError(rpcerr.RateLimited, s.caller,
+			fmt.Sprintf("Message rate limit of %s exceeded before the deadline", s.method), 0)
+	}
+	return s.ServerStream.RecvMsg(m)
+}
+
+func (l *RateLimiter) allow(caller, method string) error {
+	limit, ok := l.opts.Methods[method]
+	if !ok {
+		limit = l.opts.Default
+	}
+	if limit.Rate <= 0 {
+		return nil
+	}
+	r := l.limiter(limiterKey{caller, method, false}, limit).Reserve()
+	if !r.OK() {
+		return resourceExhaustedError(rpcerr.RateLimited, caller, fmt.Sprintf("Rate limit of %s exceeded", method), 0)
+	}
+	if delay := r.Delay(); delay > 0 {
+		r.Cancel()
+		return resourceExhaustedError(rpcerr.RateLimited, caller,
+			fmt.Sprintf("Rate limit of %g calls per second to %s exceeded", float64(limit.Rate), method), delay)
+	}
+	return nil
+}
+
+// acquireStream takes a stream slot of the caller, returning the function
+// releasing it.
+func (l *RateLimiter) acquireStream(caller string) (func(), error) {
+	if l.opts.MaxStreams <= 0 && l.opts.MaxStreamsPerCaller <= 0 {
+		return func() {}, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.opts.MaxStreams > 0 && l.streams >= l.opts.MaxStreams {
+		return nil, resourceExhaustedError(rpcerr.TooManyStreams, "server", fmt.Sprintf("Server limit of %d concurrent streams reached", l.opts.MaxStreams), 0)
+	}
+	if l.opts.MaxStreamsPerCaller > 0 && l.callerStreams[caller] >= l.opts.MaxStreamsPerCaller {
+		return nil, resourceExhaustedError(rpcerr.TooManyStreams, caller, fmt.Sprintf("Limit of %d concurrent streams per caller reached", l.opts.MaxStreamsPerCaller), 0)
+	}
+	l.streams++
+	l.callerStreams[caller]++
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.streams--
+		if l.callerStreams[caller]--; l.callerStreams[caller] == 0 {
+			delete(l.callerStreams, caller)
+		}
+	}, nil
+}
+
+func (l *RateLimiter) limiter(key limiterKey, limit RateLimit) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if now.Sub(l.lastSweep) > limiterIdleTTL {
+		l.sweep(now)
+	}
+	cl, ok := l.limiters[key]
+	if !ok {
+		cl = &callerLimiter{Limiter: rate.NewLimiter(limit.Rate, max(limit.Burst, 1))}
+		l.limiters[key] = cl
+	}
+	cl.lastUsed = now
+	return cl.Limiter
+}
+
+// sweep drops the limiters unused for limiterIdleTTL. l.mu must be held.
+func (l *RateLimiter) sweep(now time.Time) {
+	for key, cl := range l.limiters {
+		if now.Sub(cl.lastUsed) > limiterIdleTTL {
+			delete(l.limiters, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// callerFromContext identifies the caller by its authenticated subject, or
+// by its IP address when the server doesn't authenticate callers.
+func callerFromContext(ctx context.Context) string {
+	if id, ok := auth.FromContext(ctx); ok {
+		return "subject:" + id.Subject
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "ip:" + host
+	}
+	return "unknown"
+}
+
+// resourceExhaustedError builds a ResourceExhausted status carrying a
+// QuotaFailure for the subject and, when retryAfter is positive, a
+// RetryInfo.
+func resourceExhaustedError(reason rpcerr.Reason, subject, description string, retryAfter time.Duration) error {
+	details := []protoadapt.MessageV1{&epb.QuotaFailure{
+		Violations: []*epb.QuotaFailure_Violation{{Subject: subject, Description: description}},
+	}}
+	if retryAfter > 0 {
+		details = append(details, &epb.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	}
+	return rpcerr.New(codes.ResourceExhausted, reason, description, details...)
+}
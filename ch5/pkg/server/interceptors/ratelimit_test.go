@@ -0,0 +1,231 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"ch3/svc/pkg/auth"
+	"ch3/svc/pkg/rpcerr"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type callerKey struct{}
+
+// testCaller identifies callers by the name ctx carries.
+func testCaller(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+func withCaller(caller string) context.Context {
+	return context.WithValue(context.Background(), callerKey{}, caller)
+}
+
+func okHandler(context.Context, any) (any, error) { return "ok", nil }
+
+// callUnary calls method through the unary interceptor of l as caller.
+func callUnary(l *RateLimiter, caller, method string) error {
+	_, err := l.UnaryServerInterceptor()(withCaller(caller), nil, &grpc.UnaryServerInfo{FullMethod: method}, okHandler)
+	return err
+}
+
+// rarely refills a bucket once an hour, never during a test.
+var rarely = rate.Every(time.Hour)
+
+func TestRateLimiterUnary(t *testing.T) {
+	l := NewRateLimiter(RateLimitOptions{
+		Default: RateLimit{Rate: rarely, Burst: 2},
+		Methods: map[string]RateLimit{"/unlimited": {}},
+		Exempt:  []string{"/exempt"},
+		Caller:  testCaller,
+	})
+	for i := range 2 {
+		if err := callUnary(l, "alice", "/get"); err != nil {
+			t.Fatalf("call %d within the burst failed: %v", i, err)
+		}
+	}
+	err := callUnary(l, "alice", "/get")
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("call over the burst = %v, want ResourceExhausted", err)
+	}
+	if rpcerr.ReasonOf(err) != rpcerr.RateLimited {
+		t.Errorf("reason = %s, want %s", rpcerr.ReasonOf(err), rpcerr.RateLimited)
+	}
+	if delay, ok := rpcerr.RetryDelay(err); !ok || delay <= 0 {
+		t.Errorf("RetryInfo delay = %v, %t, want the wait for the next token", delay, ok)
+	}
+	if violations := rpcerr.QuotaViolations(err); len(violations) != 1 || violations[0].Subject != "alice" {
+		t.Errorf("quota violations = %v, want one for alice", violations)
+	}
+
+	// The buckets are per caller and method.
+	if err := callUnary(l, "bob", "/get"); err != nil {
+		t.Errorf("call of another caller failed: %v", err)
+	}
+	if err := callUnary(l, "alice", "/list"); err != nil {
+		t.Errorf("call to another method failed: %v", err)
+	}
+	for _, method := range []string{"/unlimited", "/exempt"} {
+		for range 3 {
+			if err := callUnary(l, "alice", method); err != nil {
+				t.Errorf("call to %s failed: %v", method, err)
+			}
+		}
+	}
+}
+
+func TestRateLimiterZeroBurst(t *testing.T) {
+	// A burst of 0 lets one call through rather than none.
+	l := NewRateLimiter(RateLimitOptions{Default: RateLimit{Rate: rarely}, Caller: testCaller})
+	if err := callUnary(l, "alice", "/get"); err != nil {
+		t.Fatalf("first call failed: %v", err)
+	}
+	if err := callUnary(l, "alice", "/get"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("second call = %v, want ResourceExhausted", err)
+	}
+}
+
+// recvStream is a server stream receiving messages without end.
+type recvStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *recvStream) Context() context.Context { return s.ctx }
+func (s *recvStream) RecvMsg(any) error        { return nil }
+
+func TestRateLimiterCapsStreams(t *testing.T) {
+	l := NewRateLimiter(RateLimitOptions{MaxStreams: 3, MaxStreamsPerCaller: 2, Caller: testCaller})
+	interceptor := l.StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/watch"}
+
+	// open starts a stream of caller, held open until release is closed.
+	release := make(chan struct{})
+	open := func(caller string) <-chan error {
+		started, done := make(chan struct{}), make(chan error, 1)
+		go func() {
+			done <- interceptor(nil, &recvStream{ctx: withCaller(caller)}, info, func(any, grpc.ServerStream) error {
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		select {
+		case <-started:
+		case err := <-done:
+			done <- err
+		}
+		return done
+	}
+
+	var held []<-chan error
+	for _, caller := range []string{"alice", "alice", "bob"} {
+		held = append(held, open(caller))
+	}
+	for _, tt := range []struct{ caller, limit string }{{"alice", "per caller"}, {"carol", "server"}} {
+		select {
+		case err := <-open(tt.caller):
+			if status.Code(err) != codes.ResourceExhausted || rpcerr.ReasonOf(err) != rpcerr.TooManyStreams {
+				t.Errorf("stream over the %s cap = %v, want ResourceExhausted %s", tt.limit, err, rpcerr.TooManyStreams)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("stream over the %s cap was accepted", tt.limit)
+		}
+	}
+
+	close(release)
+	for _, done := range held {
+		if err := <-done; err != nil {
+			t.Errorf("held stream failed: %v", err)
+		}
+	}
+	// The slots are released with the streams.
+	if err := interceptor(nil, &recvStream{ctx: withCaller("alice")}, info, func(any, grpc.ServerStream) error { return nil }); err != nil {
+		t.Errorf("stream after the others ended failed: %v", err)
+	}
+}
+
+func TestRateLimiterSlowsDownStreamMessages(t *testing.T) {
+	const perSecond = 20
+	l := NewRateLimiter(RateLimitOptions{
+		StreamMessages: map[string]RateLimit{"/pack": {Rate: perSecond, Burst: 1}},
+		Caller:         testCaller,
+	})
+	start := time.Now()
+	err := l.StreamServerInterceptor()(nil, &recvStream{ctx: withCaller("alice")}, &grpc.StreamServerInfo{FullMethod: "/pack"},
+		func(_ any, ss grpc.ServerStream) error {
+			for range 5 {
+				if err := ss.RecvMsg(nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+	// The first message takes the burst, the 4 others wait for a token.
+	if elapsed, want := time.Since(start), 4*time.Second/perSecond; elapsed < want*9/10 {
+		t.Errorf("received 5 messages in %v, want at least %v", elapsed, want)
+	}
+}
+
+func TestRateLimiterFailsStreamMessagesPastDeadline(t *testing.T) {
+	l := NewRateLimiter(RateLimitOptions{
+		StreamMessages: map[string]RateLimit{"/pack": {Rate: rarely, Burst: 1}},
+		Caller:         testCaller,
+	})
+	ctx, cancel := context.WithTimeout(withCaller("alice"), time.Minute)
+	defer cancel()
+	var received int
+	err := l.StreamServerInterceptor()(nil, &countingStream{recvStream: recvStream{ctx: ctx}, received: &received}, &grpc.StreamServerInfo{FullMethod: "/pack"},
+		func(_ any, ss grpc.ServerStream) error {
+			for range 5 {
+				if err := ss.RecvMsg(nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	// The bucket is empty after the first message, and refills after the
+	// deadline.
+	if status.Code(err) != codes.ResourceExhausted || rpcerr.ReasonOf(err) != rpcerr.RateLimited {
+		t.Errorf("stream error = %v, want ResourceExhausted %s", err, rpcerr.RateLimited)
+	}
+	if received != 1 {
+		t.Errorf("received %d messages, want 1", received)
+	}
+}
+
+// countingStream counts the messages received.
+type countingStream struct {
+	recvStream
+	received *int
+}
+
+func (s *countingStream) RecvMsg(m any) error {
+	*s.received++
+	return s.recvStream.RecvMsg(m)
+}
+
+func TestCallerFromContext(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 4242}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	if got := callerFromContext(ctx); got != "ip:192.0.2.1" {
+		t.Errorf("caller of the peer = %q, want ip:192.0.2.1", got)
+	}
+	ctx = auth.NewContext(ctx, &auth.Identity{Subject: "alice"})
+	if got := callerFromContext(ctx); got != "subject:alice" {
+		t.Errorf("caller of the identity = %q, want subject:alice", got)
+	}
+	if got := callerFromContext(context.Background()); got != "unknown" {
+		t.Errorf("caller without peer = %q, want unknown", got)
+	}
+}
@@ -0,0 +1,114 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+
+	"ch3/svc/pkg/rpcerr"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+)
+
+// RecoveryOptions configures the recovery interceptors.
+type RecoveryOptions struct {
+	// Logger receives the panics along with their stack. slog.Default() is
+	// used when nil.
+	Logger *slog.Logger
+	// Debug adds the stack of the panic to the DebugInfo sent to the
+	// caller. Leave it off in production, the stack reveals the server
+	// internals.
+	Debug bool
+}
+
+// Recovery turns the panics of the RPC handlers into Internal errors
+// instead of crashing the server. Each panic is logged with its stack and
+// counted in the grpc_server_panics_recovered_total metric; Recovery is a
+// prometheus.Collector to register with a prometheus.Registerer.
+//
+// Only the panics of the goroutine running the handler are recovered, the
+// goroutines started by handlers must recover their own.
+type Recovery struct {
+	opts   RecoveryOptions
+	panics *prometheus.CounterVec
+}
+
+func NewRecovery(opts RecoveryOptions) *Recovery {
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	return &Recovery{
+		opts: opts,
+		panics: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_panics_recovered_total",
+			Help: "Total number of panics recovered in the RPC handlers of the server.",
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+	}
+}
+
+func (r *Recovery) Describe(ch chan<- *prometheus.Desc) {
+	r.panics.Describe(ch)
+}
+
+func (r *Recovery) Collect(ch chan<- prometheus.Metric) {
+	r.panics.Collect(ch)
+}
+
+// UnaryServerInterceptor recovers the panics of unary RPC handlers.
+func (r *Recovery) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				resp, err = nil, r.recovered(ctx, unaryType, info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor recovers the panics of streaming RPC handlers.
+func (r *Recovery) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = r.recovered(ss.Context(), rpcType(info.IsClientStream, info.IsServerStream), info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recovered logs and counts the panic p of an RPC, and returns the error
+// sent to the caller in its place.
+func (r *Recovery) recovered(ctx context.Context, typ, fullMethod string, p any) error {
+	stack := string(debug.Stack())
+	service, method := splitMethod(fullMethod)
+	r.panics.WithLabelValues(typ, service, method).Inc()
+
+	attrs := []slog.Attr{
+		slog.String("grpc.service", service),
+		slog.String("grpc.method", method),
+		slog.String("panic", fmt.Sprint(p)),
+		slog.String("stack", stack),
+	}
+	if pr, ok := peer.FromContext(ctx); ok && pr.Addr != nil {
+		attrs = append(attrs, slog.String("peer.address", pr.Addr.String()))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	r.opts.Logger.LogAttrs(ctx, slog.LevelError, "recovered from panic", attrs...)
+
+	info := &epb.DebugInfo{Detail: fmt.Sprintf("panic: %v", p)}
+	if r.opts.Debug {
+		info.StackEntries = strings.Split(strings.TrimSpace(stack), "\n")
+	}
+	return rpcerr.New(codes.Internal, rpcerr.Internal, "internal error", info)
+}
@@ -0,0 +1,70 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"ch3/svc/pkg/rpcerr"
+	"ch3/svc/pkg/validate"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// ValidationOptions configures the validation interceptors.
+type ValidationOptions struct {
+	// Exempt lists the full names of the methods whose handlers validate
+	// their requests themselves, e.g. to report the invalid messages of a
+	// stream one by one instead of failing it.
+	Exempt []string
+}
+
+// UnaryServerValidation rejects the requests breaking the (ecommerce.v1.rules)
+// of their fields with InvalidArgument, carrying a BadRequest that lists
+// every violation.
+func UnaryServerValidation(opts ValidationOptions) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !slices.Contains(opts.Exempt, info.FullMethod) {
+			if err := validateMessage(req); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerValidation validates every message received on a stream like
+// UnaryServerValidation, failing the RecvMsg of invalid messages.
+func StreamServerValidation(opts ValidationOptions) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if slices.Contains(opts.Exempt, info.FullMethod) {
+			return handler(srv, ss)
+		}
+		return handler(srv, &validatingStream{ServerStream: ss})
+	}
+}
+
+// validatingStream validates the messages it receives.
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validateMessage(m)
+}
+
+func validateMessage(m any) error {
+	pm, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+	violations := validate.Message(pm)
+	if len(violations) == 0 {
+		return nil
+	}
+	return rpcerr.InvalidArgument(fmt.Sprintf("invalid %s", pm.ProtoReflect().Descriptor().Name()), violations...)
+}
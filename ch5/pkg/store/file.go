@@ -0,0 +1,377 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	logFileName      = "orders.log"
+	snapshotFileName = "orders.snapshot"
+	probeFileName    = "health.probe"
+
+	DefaultSnapshotEvery = 1000
+)
+
+// logRecord is a single line of the append-only order log.
+type logRecord struct {
+	Op     string  `json:"op"`
+	Orders []Order `json:"orders"`
+}
+
+const opPut = "put"
+
+// FileOrderStore is an OrderStore keeping orders in memory and persisting
+// every write to an append-only log in its directory. The log is compacted
+// into a snapshot every snapshotEvery writes and on Close. Opening the store
+// loads the snapshot and replays the log on top of it.
+type FileOrderStore struct {
+	*MemoryOrderStore
+
+	// mu serializes writes to the log and the snapshot.
+	mu  sync.Mutex
+	dir string
+	log logFile
+	// failed is set when a failed write couldn't be removed from the log,
+	// failing the writes after it rather than appending to a torn record.
+	failed        error
+	writes        int
+	snapshotEvery int
+	onChange      ChangeFunc
+}
+
+// logFile is the open order log, an *os.File.
+type logFile interface {
+	io.WriteSeeker
+	io.Closer
+	Truncate(size int64) error
+	Sync() error
+}
+
+var (
+	_ OrderStore    = (*FileOrderStore)(nil)
+	_ HealthChecker = (*FileOrderStore)(nil)
+)
+
+// OpenFileOrderStore opens (creating if needed) the order store in dir.
+// A non-positive snapshotEvery falls back to DefaultSnapshotEvery.
+func OpenFileOrderStore(dir string, snapshotEvery int) (*FileOrderStore, error) {
+	if snapshotEvery <= 0 {
+		snapshotEvery = DefaultSnapshotEvery
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create order store directory: %w", err)
+	}
+	s := &FileOrderStore{
+		MemoryOrderStore: NewMemoryOrderStore(),
+		dir:              dir,
+		snapshotEvery:    snapshotEvery,
+	}
+	if err := s.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	if err := s.replayLog(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileOrderStore) Create(_ context.Context, order Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.MemoryOrderStore.Get(context.Background(), order.Id); err == nil {
+		return ErrAlreadyExists
+	}
+	if err := s.write(logRecord{Op: opPut, Orders: []Order{order}}); err != nil {
+		return err
+	}
+	s.changed(order, true)
+	return nil
+}
+
+// CreateMany writes all the orders as a single log record, so that a crash
+// can't persist only some of them.
+func (s *FileOrderStore) CreateMany(_ context.Context, orders []Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make(map[string]bool, len(orders))
+	for _, order := range orders {
+		if _, err := s.MemoryOrderStore.Get(context.Background(), order.Id); err == nil || ids[order.Id] {
+			return ErrAlreadyExists
+		}
+		ids[order.Id] = true
+	}
+	if err := s.write(logRecord{Op: opPut, Orders: orders}); err != nil {
+		return err
+	}
+	for _, order := range orders {
+		s.changed(order, true)
+	}
+	return nil
+}
+
+func (s *FileOrderStore) Update(_ context.Context, id string, fn func(order *Order) error) (Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	order, err := s.MemoryOrderStore.Get(context.Background(), id)
+	if err != nil {
+		return Order{}, err
+	}
+	order.Items = slices.Clone(order.Items)
+	if err := fn(&order); err != nil {
+		return Order{}, err
+	}
+	order.Id = id
+	if err := s.write(logRecord{Op: opPut, Orders: []Order{order}}); err != nil {
+		return Order{}, err
+	}
+	s.changed(order, false)
+	return order, nil
+}
+
+func (s *FileOrderStore) OnChange(fn ChangeFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = fn
+}
+
+// changed reports a write to the OnChange func. s.mu must be held.
+func (s *FileOrderStore) changed(order Order, created bool) {
+	if s.onChange != nil {
+		s.onChange(order, created)
+	}
+}
+
+// Check fails once the store is closed or failed, or when a probe file can't be
+// durably written to its directory, e.g. because the directory is gone or
+// the disk is full.
+func (s *FileOrderStore) Check(_ context.Context) error {
+	s.mu.Lock()
+	closed, failed := s.log == nil, s.failed
+	s.mu.Unlock()
+	if closed {
+		return errors.New("order store is closed")
+	}
+	if failed != nil {
+		return failed
+	}
+	probe := []byte(time.Now().UTC().Format(time.RFC3339Nano))
+	if err := writeFileAtomic(filepath.Join(s.dir, probeFileName), probe); err != nil {
+		return fmt.Errorf("order store directory is not writable: %w", err)
+	}
+	return nil
+}
+
+// Close compacts the log into a snapshot and closes the log file.
+func (s *FileOrderStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.log == nil {
+		return nil
+	}
+	err := s.snapshot()
+	if closeErr := s.log.Close(); err == nil {
+		err = closeErr
+	}
+	s.log = nil
+	return err
+}
+
+// write durably appends rec to the log before applying it in memory. A
+// record that fails to be written or synced is cut off the log, so that it
+// neither tears the log nor comes back on replay. s.mu must be held.
+func (s *FileOrderStore) write(rec logRecord) error {
+	if s.log == nil {
+		return errors.New("order store is closed")
+	}
+	if s.failed != nil {
+		return s.failed
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode order log record: %w", err)
+	}
+	offset, err := s.log.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("failed to locate the end of the order log: %w", err)
+	}
+	if _, err := s.log.Write(append(line, '\n')); err != nil {
+		s.rollback(offset)
+		return fmt.Errorf("failed to append to order log: %w", err)
+	}
+	if err := s.log.Sync(); err != nil {
+		s.rollback(offset)
+		return fmt.Errorf("failed to sync order log: %w", err)
+	}
+	s.apply(rec)
+
+	s.writes++
+	if s.writes >= s.snapshotEvery {
+		// The write itself is already durable in the log, a failed
+		// compaction is retried on the next write.
+		if err := s.snapshot(); err != nil {
+			log.Printf("failed to snapshot orders: %v", err)
+		}
+	}
+	return nil
+}
+
+// rollback cuts the log back to offset, the end of the last record written.
+// When it can't, the store is failed. s.mu must be held.
+func (s *FileOrderStore) rollback(offset int64) {
+	err := s.log.Truncate(offset)
+	if err == nil {
+		_, err = s.log.Seek(offset, io.SeekStart)
+	}
+	if err != nil {
+		s.failed = fmt.Errorf("order log is torn after a failed write: %w", err)
+		log.Printf("failed to roll back the order log: %v", err)
+	}
+}
+
+func (s *FileOrderStore) apply(rec logRecord) {
+	switch rec.Op {
+	case opPut:
+		for _, order := range rec.Orders {
+			s.MemoryOrderStore.put(withDefaults(order))
+		}
+	}
+}
+
+// snapshot writes all the orders to the snapshot file and truncates the log.
+// s.mu must be held.
+func (s *FileOrderStore) snapshot() error {
+	orders, err := s.MemoryOrderStore.List(context.Background())
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(orders)
+	if err != nil {
+		return fmt.Errorf("failed to encode order snapshot: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(s.dir, snapshotFileName), data); err != nil {
+		return fmt.Errorf("failed to write order snapshot: %w", err)
+	}
+	// Records replayed on top of a fresh snapshot are idempotent puts, so a
+	// crash before the log is truncated loses nothing.
+	if err := s.log.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate order log: %w", err)
+	}
+	if _, err := s.log.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind order log: %w", err)
+	}
+	s.writes = 0
+	return nil
+}
+
+func (s *FileOrderStore) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(s.dir, snapshotFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read order snapshot: %w", err)
+	}
+	var orders []Order
+	if err := json.Unmarshal(data, &orders); err != nil {
+		return fmt.Errorf("failed to decode order snapshot: %w", err)
+	}
+	for _, order := range orders {
+		s.MemoryOrderStore.put(withDefaults(order))
+	}
+	return nil
+}
+
+// replayLog applies the log records on top of the snapshot and opens the log
+// for appending. A torn record at the end of the log, left by a crash in the
+// middle of a write, is discarded.
+func (s *FileOrderStore) replayLog() error {
+	f, err := os.OpenFile(filepath.Join(s.dir, logFileName), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open order log: %w", err)
+	}
+	var (
+		offset int64
+		r      = bufio.NewReader(f)
+	)
+	for {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// Anything after the last newline is an incomplete record.
+			break
+		}
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("failed to read order log: %w", err)
+		}
+		var rec logRecord
+		if err := json.Unmarshal(bytes.TrimSpace(line), &rec); err != nil {
+			if _, peekErr := r.Peek(1); errors.Is(peekErr, io.EOF) {
+				break
+			}
+			f.Close()
+			return fmt.Errorf("order log is corrupted at offset %d: %w", offset, err)
+		}
+		s.apply(rec)
+		s.writes++
+		offset += int64(len(line))
+	}
+	if err := f.Truncate(offset); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to truncate order log: %w", err)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to seek order log: %w", err)
+	}
+	s.log = f
+	return nil
+}
+
+// withDefaults fills in the fields missing from orders persisted by older
+// versions of the store.
+func withDefaults(order Order) Order {
+	if order.Status == "" {
+		order.Status = StatusCreated
+	}
+	return order
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
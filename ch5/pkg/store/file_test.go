@@ -0,0 +1,224 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testOrder(id string, price float32) Order {
+	return Order{Id: id, Price: price, Status: StatusCreated, CreatedAt: time.Unix(1700000000, 0).UTC()}
+}
+
+func openTestStore(t *testing.T, dir string, snapshotEvery int) *FileOrderStore {
+	t.Helper()
+	s, err := OpenFileOrderStore(dir, snapshotEvery)
+	if err != nil {
+		t.Fatalf("OpenFileOrderStore: %v", err)
+	}
+	return s
+}
+
+// wantOrders checks that s holds exactly the orders of want, keyed by id.
+func wantOrders(t *testing.T, s OrderStore, want map[string]Order) {
+	t.Helper()
+	orders, err := s.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(orders) != len(want) {
+		t.Errorf("got %d orders, want %d", len(orders), len(want))
+	}
+	for _, order := range orders {
+		w, ok := want[order.Id]
+		if !ok {
+			t.Errorf("unexpected order %s", order.Id)
+			continue
+		}
+		if order.Price != w.Price || order.Status != w.Status || !order.CreatedAt.Equal(w.CreatedAt) {
+			t.Errorf("order %s = %+v, want %+v", order.Id, order, w)
+		}
+	}
+}
+
+func TestFileOrderStoreReplaysLog(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := openTestStore(t, dir, 100)
+	if err := s.Create(ctx, testOrder("a", 1)); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := s.CreateMany(ctx, []Order{testOrder("b", 2), testOrder("c", 3)}); err != nil {
+		t.Fatalf("CreateMany: %v", err)
+	}
+	if _, err := s.Update(ctx, "b", Transition(StatusCancelled)); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	// Reopen without closing, as after a crash: only the log is there.
+	if _, err := os.Stat(filepath.Join(dir, snapshotFileName)); !os.IsNotExist(err) {
+		t.Fatalf("snapshot written before Close: %v", err)
+	}
+	s.log.Close()
+
+	want := map[string]Order{
+		"a": testOrder("a", 1),
+		"b": {Id: "b", Price: 2, Status: StatusCancelled, CreatedAt: testOrder("b", 2).CreatedAt},
+		"c": testOrder("c", 3),
+	}
+	reopened := openTestStore(t, dir, 100)
+	defer reopened.Close()
+	wantOrders(t, reopened, want)
+	if err := reopened.Create(ctx, testOrder("a", 1)); err != ErrAlreadyExists {
+		t.Errorf("Create of a replayed order = %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestFileOrderStoreSnapshots(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := openTestStore(t, dir, 2)
+	for _, order := range []Order{testOrder("a", 1), testOrder("b", 2), testOrder("c", 3)} {
+		if err := s.Create(ctx, order); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	// The first two writes were compacted, the third one is in the log.
+	if _, err := os.Stat(filepath.Join(dir, snapshotFileName)); err != nil {
+		t.Fatalf("no snapshot after snapshotEvery writes: %v", err)
+	}
+	s.log.Close()
+
+	reopened := openTestStore(t, dir, 2)
+	wantOrders(t, reopened, map[string]Order{"a": testOrder("a", 1), "b": testOrder("b", 2), "c": testOrder("c", 3)})
+	if err := reopened.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if info, err := os.Stat(filepath.Join(dir, logFileName)); err != nil || info.Size() != 0 {
+		t.Errorf("log not truncated by Close: %v, %v", info, err)
+	}
+
+	reopened = openTestStore(t, dir, 2)
+	defer reopened.Close()
+	wantOrders(t, reopened, map[string]Order{"a": testOrder("a", 1), "b": testOrder("b", 2), "c": testOrder("c", 3)})
+}
+
+func TestFileOrderStoreDiscardsTornRecord(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := openTestStore(t, dir, 100)
+	if err := s.Create(ctx, testOrder("a", 1)); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	// A crash in the middle of a write leaves an incomplete last line.
+	if _, err := s.log.Write([]byte(`{"op":"put","orders":[{"id":"b"`)); err != nil {
+		t.Fatal(err)
+	}
+	s.log.Close()
+
+	reopened := openTestStore(t, dir, 100)
+	wantOrders(t, reopened, map[string]Order{"a": testOrder("a", 1)})
+	// New records are appended right after the last complete one.
+	if err := reopened.Create(ctx, testOrder("c", 3)); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	reopened.log.Close()
+
+	reopened = openTestStore(t, dir, 100)
+	defer reopened.Close()
+	wantOrders(t, reopened, map[string]Order{"a": testOrder("a", 1), "c": testOrder("c", 3)})
+}
+
+// faultyLog fails the writes after writing half of them, as on a full
+// disk, or the syncs.
+type faultyLog struct {
+	logFile
+	failWrite, failSync bool
+}
+
+func (l *faultyLog) Write(p []byte) (int, error) {
+	if !l.failWrite {
+		return l.logFile.Write(p)
+	}
+	n, _ := l.logFile.Write(p[:len(p)/2])
+	return n, errors.New("no space left on device")
+}
+
+func (l *faultyLog) Sync() error {
+	if l.failSync {
+		return errors.New("input/output error")
+	}
+	return l.logFile.Sync()
+}
+
+func TestFileOrderStoreRollsBackFailedWrites(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		log  faultyLog
+	}{
+		{name: "partial write", log: faultyLog{failWrite: true}},
+		{name: "failed sync", log: faultyLog{failSync: true}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dir := t.TempDir()
+			s := openTestStore(t, dir, 100)
+			if err := s.Create(ctx, testOrder("a", 1)); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			faulty := tt.log
+			faulty.logFile = s.log
+			s.log = &faulty
+			if err := s.Create(ctx, testOrder("b", 2)); err == nil {
+				t.Fatal("Create succeeded despite the failed write")
+			}
+			if _, err := s.Get(ctx, "b"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get of the failed order = %v, want ErrNotFound", err)
+			}
+
+			// Writes go on once the disk recovers.
+			faulty.failWrite, faulty.failSync = false, false
+			if err := s.Create(ctx, testOrder("c", 3)); err != nil {
+				t.Fatalf("Create after the failure: %v", err)
+			}
+			if err := s.Check(ctx); err != nil {
+				t.Errorf("Check: %v", err)
+			}
+			s.log.Close()
+
+			reopened := openTestStore(t, dir, 100)
+			defer reopened.Close()
+			wantOrders(t, reopened, map[string]Order{"a": testOrder("a", 1), "c": testOrder("c", 3)})
+		})
+	}
+}
+
+func TestFileOrderStoreRejectsCorruptedLog(t *testing.T) {
+	dir := t.TempDir()
+	log := "{\"op\":\"put\",\"orders\":[{\"id\":\"a\"}]}\nnot json\n{\"op\":\"put\",\"orders\":[{\"id\":\"b\"}]}\n"
+	if err := os.WriteFile(filepath.Join(dir, logFileName), []byte(log), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenFileOrderStore(dir, 100); err == nil {
+		t.Fatal("OpenFileOrderStore succeeded on a log corrupted in the middle")
+	}
+}
+
+func TestFileOrderStoreFillsMissingStatus(t *testing.T) {
+	dir := t.TempDir()
+	log := "{\"op\":\"put\",\"orders\":[{\"id\":\"a\",\"price\":1}]}\n"
+	if err := os.WriteFile(filepath.Join(dir, logFileName), []byte(log), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s := openTestStore(t, dir, 100)
+	defer s.Close()
+	order, err := s.Get(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if order.Status != StatusCreated {
+		t.Errorf("Status = %q, want %q", order.Status, StatusCreated)
+	}
+}
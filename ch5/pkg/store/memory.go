@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// MemoryOrderStore is an OrderStore holding orders in a map.
+type MemoryOrderStore struct {
+	mu       sync.RWMutex
+	orders   map[string]Order
+	onChange ChangeFunc
+}
+
+var _ OrderStore = (*MemoryOrderStore)(nil)
+
+func NewMemoryOrderStore() *MemoryOrderStore {
+	return &MemoryOrderStore{orders: make(map[string]Order)}
+}
+
+func (s *MemoryOrderStore) Create(_ context.Context, order Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.orders[order.Id]; ok {
+		return ErrAlreadyExists
+	}
+	s.orders[order.Id] = order
+	s.changed(order, true)
+	return nil
+}
+
+func (s *MemoryOrderStore) CreateMany(_ context.Context, orders []Order) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, order := range orders {
+		if _, ok := s.orders[order.Id]; ok {
+			return ErrAlreadyExists
+		}
+	}
+	for _, order := range orders {
+		s.orders[order.Id] = order
+		s.changed(order, true)
+	}
+	return nil
+}
+
+func (s *MemoryOrderStore) Get(_ context.Context, id string) (Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	order, ok := s.orders[id]
+	if !ok {
+		return Order{}, ErrNotFound
+	}
+	return order, nil
+}
+
+func (s *MemoryOrderStore) Update(_ context.Context, id string, fn func(order *Order) error) (Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	order, ok := s.orders[id]
+	if !ok {
+		return Order{}, ErrNotFound
+	}
+	order.Items = slices.Clone(order.Items)
+	if err := fn(&order); err != nil {
+		return Order{}, err
+	}
+	order.Id = id
+	s.orders[id] = order
+	s.changed(order, false)
+	return order, nil
+}
+
+func (s *MemoryOrderStore) List(_ context.Context) ([]Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	orders := make([]Order, 0, len(s.orders))
+	for _, order := range s.orders {
+		orders = append(orders, order)
+	}
+	slices.SortFunc(orders, Order.Compare)
+	return orders, nil
+}
+
+func (s *MemoryOrderStore) OnChange(fn ChangeFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = fn
+}
+
+// changed reports a write to the OnChange func. s.mu must be held.
+func (s *MemoryOrderStore) changed(order Order, created bool) {
+	if s.onChange != nil {
+		s.onChange(order, created)
+	}
+}
+
+// put creates or replaces the order.
+func (s *MemoryOrderStore) put(order Order) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders[order.Id] = order
+}
@@ -0,0 +1,115 @@
+// Package store defines storage backends for the OrderManagementService.
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound      = errors.New("order not found")
+	ErrAlreadyExists = errors.New("order already exists")
+)
+
+type Order struct {
+	Id        string      `json:"id"`
+	Price     float32     `json:"price"`
+	Items     []OrderItem `json:"items,omitempty"`
+	Status    OrderStatus `json:"status"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Compare orders them by creation time, then by id.
+func (o Order) Compare(other Order) int {
+	if c := o.CreatedAt.Compare(other.CreatedAt); c != 0 {
+		return c
+	}
+	return strings.Compare(o.Id, other.Id)
+}
+
+// OrderItem is an ordered product with its price frozen at order creation.
+type OrderItem struct {
+	ProductId string  `json:"product_id"`
+	Quantity  uint32  `json:"quantity"`
+	UnitPrice float32 `json:"unit_price"`
+}
+
+// OrderStore persists orders. Implementations must be safe for concurrent use.
+type OrderStore interface {
+	// Create stores a new order, keyed by its id.
+	Create(ctx context.Context, order Order) error
+	// CreateMany stores all the orders or, on error, none of them.
+	CreateMany(ctx context.Context, orders []Order) error
+	// Get returns the order with the given id or ErrNotFound.
+	Get(ctx context.Context, id string) (Order, error)
+	// Update atomically applies fn to the stored order and returns the
+	// updated order. The order is left untouched if fn returns an error.
+	Update(ctx context.Context, id string, fn func(order *Order) error) (Order, error)
+	// List returns all the stored orders, sorted with Order.Compare.
+	List(ctx context.Context) ([]Order, error)
+	// OnChange sets the func called with every order the store creates or
+	// updates. It must be set before the store is used.
+	OnChange(fn ChangeFunc)
+}
+
+// ChangeFunc is called by a store once it has written an order, before it
+// releases its lock: calls follow the order of the writes, even concurrent
+// ones. It must not call the store.
+type ChangeFunc func(order Order, created bool)
+
+// HealthChecker is implemented by the stores that can become unhealthy,
+// e.g. when they lose their storage.
+type HealthChecker interface {
+	// Check returns an error when the store can't serve requests.
+	Check(ctx context.Context) error
+}
+
+// OrderStatus is the lifecycle state of an order.
+type OrderStatus string
+
+const (
+	StatusCreated   OrderStatus = "CREATED"
+	StatusPacked    OrderStatus = "PACKED"
+	StatusShipped   OrderStatus = "SHIPPED"
+	StatusDelivered OrderStatus = "DELIVERED"
+	StatusCancelled OrderStatus = "CANCELLED"
+)
+
+// transitions lists the statuses an order may move to from each status.
+var transitions = map[OrderStatus][]OrderStatus{
+	StatusCreated: {StatusPacked, StatusCancelled},
+	StatusPacked:  {StatusShipped, StatusCancelled},
+	StatusShipped: {StatusDelivered},
+}
+
+// CanTransitionTo reports whether an order may move from status s to status to.
+func (s OrderStatus) CanTransitionTo(to OrderStatus) bool {
+	return slices.Contains(transitions[s], to)
+}
+
+// TransitionError reports an order status change the order lifecycle forbids.
+type TransitionError struct {
+	OrderId string
+	From    OrderStatus
+	To      OrderStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("order %s can't move from %s to %s", e.OrderId, e.From, e.To)
+}
+
+// Transition returns an Update func moving an order to the status to,
+// failing with a *TransitionError if the lifecycle doesn't allow it.
+func Transition(to OrderStatus) func(order *Order) error {
+	return func(order *Order) error {
+		if !order.Status.CanTransitionTo(to) {
+			return &TransitionError{OrderId: order.Id, From: order.Status, To: to}
+		}
+		order.Status = to
+		return nil
+	}
+}
@@ -0,0 +1,83 @@
+package validate
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	pb "ch3/svc/protos/ordermgt/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const testUUID = "123e4567-e89b-12d3-a456-426614174000"
+
+func lineItems(n int) []*pb.LineItem {
+	items := make([]*pb.LineItem, n)
+	for i := range items {
+		items[i] = &pb.LineItem{ProductId: testUUID, Quantity: 1}
+	}
+	return items
+}
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		m    proto.Message
+		want []string // paths of the violations
+	}{
+		{name: "nil", m: nil},
+		{name: "valid", m: &pb.CreateOrderRequest{Price: 10, Items: []*pb.LineItem{{ProductId: testUUID, Quantity: 2}}}},
+		{name: "unset fields", m: &pb.CreateOrderRequest{}},
+		{name: "required", m: &pb.ShipOrderRequest{}, want: []string{"id"}},
+		{name: "uuid", m: &pb.CancelOrderRequest{Id: "order-1"}, want: []string{"id"}},
+		{name: "min", m: &pb.CreateOrderRequest{Price: -1}, want: []string{"price"}},
+		{name: "optional min", m: &pb.GetOrdersRequest{MinPrice: proto.Float32(-1), MaxPrice: proto.Float32(0)}, want: []string{"min_price"}},
+		{name: "string max_len", m: &pb.CreateOrderRequest{IdempotencyKey: strings.Repeat("é", 257)}, want: []string{"idempotency_key"}},
+		{name: "list max_len", m: &pb.CreateOrderRequest{Items: lineItems(101)}, want: []string{"items"}},
+		{
+			name: "nested",
+			m: &pb.CreateOrderRequest{Items: []*pb.LineItem{
+				{ProductId: testUUID, Quantity: 1},
+				{ProductId: "product-1", Quantity: 1001},
+				{},
+			}},
+			want: []string{"items[1].product_id", "items[1].quantity", "items[2].product_id", "items[2].quantity"},
+		},
+		{
+			name: "oneof",
+			m:    &pb.PackOrdersRequest{Policy: &pb.PackingPolicy{Policy: &pb.PackingPolicy_FixedCount{FixedCount: 1001}, FlushAfterMs: 3600001}},
+			want: []string{"policy.fixed_count", "policy.flush_after_ms"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range Message(tt.m) {
+				got = append(got, v.Field)
+				if v.Description == "" {
+					t.Errorf("violation of %s has no description", v.Field)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageDescriptions(t *testing.T) {
+	violations := Message(&pb.CreateOrderRequest{Price: -1, Items: []*pb.LineItem{{ProductId: "product-1", Quantity: 1}}})
+	want := map[string]string{
+		"price":               "Value -1 is not valid - can't be less than 0",
+		"items[0].product_id": `Value "product-1" is not a valid UUID`,
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("got %d violations, want %d", len(violations), len(want))
+	}
+	for _, v := range violations {
+		if v.Description != want[v.Field] {
+			t.Errorf("description of %s = %q, want %q", v.Field, v.Description, want[v.Field])
+		}
+	}
+}
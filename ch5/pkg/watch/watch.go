@@ -0,0 +1,140 @@
+// Package watch fans out change events to watchers. Every published event
+// gets the next revision, and a bounded history of recent events lets
+// reconnecting watchers replay the ones they missed.
+package watch
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrCompacted is returned when watching from a revision that is no
+	// longer kept in the history.
+	ErrCompacted = errors.New("revision has been compacted")
+	// ErrFutureRevision is returned when watching from a revision later than
+	// the next one, e.g. one from before a server restart.
+	ErrFutureRevision = errors.New("revision is in the future")
+	// ErrSlowWatcher is reported by Watcher.Err when the watcher was dropped
+	// for not keeping up with the published events.
+	ErrSlowWatcher = errors.New("watcher fell behind")
+)
+
+type Event[T any] struct {
+	Revision uint64
+	Value    T
+}
+
+// Hub publishes events to its watchers. It is safe for concurrent use.
+type Hub[T any] struct {
+	mu       sync.Mutex
+	revision uint64
+	// history is a ring buffer of the last len(history) events
+	history    []Event[T]
+	next       int
+	bufferSize int
+	watchers   map[*Watcher[T]]struct{}
+}
+
+// NewHub returns a Hub keeping the last historySize events and buffering up
+// to bufferSize events per watcher before dropping it.
+func NewHub[T any](historySize, bufferSize int) *Hub[T] {
+	return &Hub[T]{
+		history:    make([]Event[T], 0, historySize),
+		bufferSize: bufferSize,
+		watchers:   make(map[*Watcher[T]]struct{}),
+	}
+}
+
+// Publish assigns the next revision to v and sends it to all the watchers.
+func (h *Hub[T]) Publish(v T) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.revision++
+	ev := Event[T]{Revision: h.revision, Value: v}
+	if len(h.history) < cap(h.history) {
+		h.history = append(h.history, ev)
+	} else if cap(h.history) > 0 {
+		h.history[h.next] = ev
+		h.next = (h.next + 1) % cap(h.history)
+	}
+	for w := range h.watchers {
+		select {
+		case w.events <- ev:
+		default:
+			h.drop(w, ErrSlowWatcher)
+		}
+	}
+	return h.revision
+}
+
+// Watch starts a watcher receiving the events from startRevision on, the
+// ones already published being replayed from the history. A zero
+// startRevision only receives new events.
+func (h *Hub[T]) Watch(startRevision uint64) (*Watcher[T], error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var replay []Event[T]
+	if startRevision != 0 {
+		if startRevision > h.revision+1 {
+			return nil, ErrFutureRevision
+		}
+		oldest := h.revision + 1 - uint64(len(h.history))
+		if startRevision < oldest {
+			return nil, ErrCompacted
+		}
+		for i := range h.history {
+			ev := h.history[(h.next+i)%len(h.history)]
+			if ev.Revision >= startRevision {
+				replay = append(replay, ev)
+			}
+		}
+	}
+	w := &Watcher[T]{events: make(chan Event[T], len(replay)+h.bufferSize)}
+	for _, ev := range replay {
+		w.events <- ev
+	}
+	h.watchers[w] = struct{}{}
+	return w, nil
+}
+
+// Unwatch stops the watcher. It's safe to call on a dropped watcher.
+func (h *Hub[T]) Unwatch(w *Watcher[T]) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.drop(w, nil)
+}
+
+// Revision returns the revision of the last published event.
+func (h *Hub[T]) Revision() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.revision
+}
+
+// drop removes the watcher and closes its channel. h.mu must be held.
+func (h *Hub[T]) drop(w *Watcher[T], err error) {
+	if _, ok := h.watchers[w]; !ok {
+		return
+	}
+	delete(h.watchers, w)
+	w.err = err
+	close(w.events)
+}
+
+type Watcher[T any] struct {
+	events chan Event[T]
+	// err is written before events is closed
+	err error
+}
+
+// Events returns the channel delivering the events in revision order.
+// It is closed when the watcher is stopped or dropped.
+func (w *Watcher[T]) Events() <-chan Event[T] {
+	return w.events
+}
+
+// Err returns why the watcher was dropped, once Events is closed.
+func (w *Watcher[T]) Err() error {
+	return w.err
+}
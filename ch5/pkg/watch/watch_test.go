@@ -0,0 +1,159 @@
+package watch
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// receive returns the events buffered for w, without blocking.
+func receive[T any](w *Watcher[T]) []Event[T] {
+	var events []Event[T]
+	for {
+		select {
+		case ev, ok := <-w.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, ev)
+		default:
+			return events
+		}
+	}
+}
+
+func revisions[T any](events []Event[T]) []uint64 {
+	var revs []uint64
+	for _, ev := range events {
+		revs = append(revs, ev.Revision)
+	}
+	return revs
+}
+
+func TestHubReplaysHistory(t *testing.T) {
+	h := NewHub[string](10, 10)
+	for _, v := range []string{"a", "b", "c"} {
+		h.Publish(v)
+	}
+	w, err := h.Watch(2)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer h.Unwatch(w)
+	h.Publish("d")
+
+	events := receive(w)
+	if got, want := revisions(events), []uint64{2, 3, 4}; !slices.Equal(got, want) {
+		t.Fatalf("revisions = %v, want %v", got, want)
+	}
+	if events[0].Value != "b" || events[2].Value != "d" {
+		t.Errorf("events = %v, want b, c, d", events)
+	}
+}
+
+func TestHubWatchFromZeroOnlyGetsNewEvents(t *testing.T) {
+	h := NewHub[string](10, 10)
+	h.Publish("a")
+	w, err := h.Watch(0)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer h.Unwatch(w)
+	h.Publish("b")
+	if got, want := revisions(receive(w)), []uint64{2}; !slices.Equal(got, want) {
+		t.Errorf("revisions = %v, want %v", got, want)
+	}
+}
+
+func TestHubCompaction(t *testing.T) {
+	h := NewHub[int](3, 10)
+	for i := range 5 {
+		h.Publish(i)
+	}
+	// Revisions 3 to 5 are kept.
+	if _, err := h.Watch(2); !errors.Is(err, ErrCompacted) {
+		t.Errorf("Watch(2) error = %v, want ErrCompacted", err)
+	}
+	w, err := h.Watch(3)
+	if err != nil {
+		t.Fatalf("Watch(3): %v", err)
+	}
+	defer h.Unwatch(w)
+	if got, want := revisions(receive(w)), []uint64{3, 4, 5}; !slices.Equal(got, want) {
+		t.Errorf("revisions = %v, want %v", got, want)
+	}
+
+	// The next revision may be watched, but not the ones after it.
+	next, err := h.Watch(6)
+	if err != nil {
+		t.Fatalf("Watch(6): %v", err)
+	}
+	h.Unwatch(next)
+	if _, err := h.Watch(7); !errors.Is(err, ErrFutureRevision) {
+		t.Errorf("Watch(7) error = %v, want ErrFutureRevision", err)
+	}
+}
+
+func TestHubWithoutHistory(t *testing.T) {
+	h := NewHub[int](0, 10)
+	h.Publish(1)
+	if _, err := h.Watch(1); !errors.Is(err, ErrCompacted) {
+		t.Errorf("Watch(1) error = %v, want ErrCompacted", err)
+	}
+	if h.Revision() != 1 {
+		t.Errorf("Revision = %d, want 1", h.Revision())
+	}
+}
+
+func TestHubDropsSlowWatchers(t *testing.T) {
+	h := NewHub[int](10, 2)
+	slow, err := h.Watch(0)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	fast, err := h.Watch(0)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer h.Unwatch(fast)
+
+	for i := range 3 {
+		h.Publish(i)
+		if i < 2 {
+			// Only fast keeps up.
+			receive(fast)
+		}
+	}
+	events := receive(slow)
+	if got, want := revisions(events), []uint64{1, 2}; !slices.Equal(got, want) {
+		t.Errorf("slow watcher revisions = %v, want %v", got, want)
+	}
+	if _, ok := <-slow.Events(); ok {
+		t.Fatal("slow watcher still open")
+	}
+	if !errors.Is(slow.Err(), ErrSlowWatcher) {
+		t.Errorf("slow watcher Err = %v, want ErrSlowWatcher", slow.Err())
+	}
+	// Unwatching a dropped watcher is a no-op.
+	h.Unwatch(slow)
+
+	if got, want := revisions(receive(fast)), []uint64{3}; !slices.Equal(got, want) {
+		t.Errorf("fast watcher revisions = %v, want %v", got, want)
+	}
+}
+
+func TestHubUnwatch(t *testing.T) {
+	h := NewHub[int](10, 10)
+	w, err := h.Watch(0)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	h.Unwatch(w)
+	h.Publish(1)
+	if _, ok := <-w.Events(); ok {
+		t.Error("unwatched watcher got an event")
+	}
+	if w.Err() != nil {
+		t.Errorf("unwatched watcher Err = %v, want nil", w.Err())
+	}
+}
@@ -11,7 +11,6 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -26,6 +25,10 @@ const (
 	OrderManagementService_GetOrder_FullMethodName     = "/ecommerce.v1.OrderManagementService/GetOrder"
 	OrderManagementService_GetOrders_FullMethodName    = "/ecommerce.v1.OrderManagementService/GetOrders"
 	OrderManagementService_PackOrders_FullMethodName   = "/ecommerce.v1.OrderManagementService/PackOrders"
+	OrderManagementService_ShipOrder_FullMethodName    = "/ecommerce.v1.OrderManagementService/ShipOrder"
+	OrderManagementService_DeliverOrder_FullMethodName = "/ecommerce.v1.OrderManagementService/DeliverOrder"
+	OrderManagementService_CancelOrder_FullMethodName  = "/ecommerce.v1.OrderManagementService/CancelOrder"
+	OrderManagementService_WatchOrders_FullMethodName  = "/ecommerce.v1.OrderManagementService/WatchOrders"
 )
 
 // OrderManagementServiceClient is the client API for OrderManagementService service.
@@ -35,8 +38,18 @@ type OrderManagementServiceClient interface {
 	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderResponse, error)
 	CreateOrders(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateOrdersRequest, CreateOrdersResponse], error)
 	GetOrder(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*GetOrderResponse, error)
-	GetOrders(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetOrdersResponse], error)
+	// GetOrders streams the orders matching the request filters, oldest first.
+	GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetOrdersResponse], error)
+	// PackOrders moves the streamed CREATED orders to PACKED and sends them
+	// back in packs. Orders not sent in a pack when the stream fails are
+	// CREATED again.
 	PackOrders(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PackOrdersRequest, PackOrdersResponse], error)
+	ShipOrder(ctx context.Context, in *ShipOrderRequest, opts ...grpc.CallOption) (*ShipOrderResponse, error)
+	// DeliverOrder records the delivery of a SHIPPED order.
+	DeliverOrder(ctx context.Context, in *DeliverOrderRequest, opts ...grpc.CallOption) (*DeliverOrderResponse, error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+	// WatchOrders streams order changes as they happen.
+	WatchOrders(ctx context.Context, in *WatchOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderEvent], error)
 }
 
 type orderManagementServiceClient struct {
@@ -80,13 +93,13 @@ func (c *orderManagementServiceClient) GetOrder(ctx context.Context, in *wrapper
 	return out, nil
 }
 
-func (c *orderManagementServiceClient) GetOrders(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetOrdersResponse], error) {
+func (c *orderManagementServiceClient) GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetOrdersResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &OrderManagementService_ServiceDesc.Streams[1], OrderManagementService_GetOrders_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[emptypb.Empty, GetOrdersResponse]{ClientStream: stream}
+	x := &grpc.GenericClientStream[GetOrdersRequest, GetOrdersResponse]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -112,6 +125,55 @@ func (c *orderManagementServiceClient) PackOrders(ctx context.Context, opts ...g
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type OrderManagementService_PackOrdersClient = grpc.BidiStreamingClient[PackOrdersRequest, PackOrdersResponse]
 
+func (c *orderManagementServiceClient) ShipOrder(ctx context.Context, in *ShipOrderRequest, opts ...grpc.CallOption) (*ShipOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShipOrderResponse)
+	err := c.cc.Invoke(ctx, OrderManagementService_ShipOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementServiceClient) DeliverOrder(ctx context.Context, in *DeliverOrderRequest, opts ...grpc.CallOption) (*DeliverOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeliverOrderResponse)
+	err := c.cc.Invoke(ctx, OrderManagementService_DeliverOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelOrderResponse)
+	err := c.cc.Invoke(ctx, OrderManagementService_CancelOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderManagementServiceClient) WatchOrders(ctx context.Context, in *WatchOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrderManagementService_ServiceDesc.Streams[3], OrderManagementService_WatchOrders_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchOrdersRequest, OrderEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrderManagementService_WatchOrdersClient = grpc.ServerStreamingClient[OrderEvent]
+
 // OrderManagementServiceServer is the server API for OrderManagementService service.
 // All implementations must embed UnimplementedOrderManagementServiceServer
 // for forward compatibility.
@@ -119,8 +181,18 @@ type OrderManagementServiceServer interface {
 	CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error)
 	CreateOrders(grpc.ClientStreamingServer[CreateOrdersRequest, CreateOrdersResponse]) error
 	GetOrder(context.Context, *wrapperspb.StringValue) (*GetOrderResponse, error)
-	GetOrders(*emptypb.Empty, grpc.ServerStreamingServer[GetOrdersResponse]) error
+	// GetOrders streams the orders matching the request filters, oldest first.
+	GetOrders(*GetOrdersRequest, grpc.ServerStreamingServer[GetOrdersResponse]) error
+	// PackOrders moves the streamed CREATED orders to PACKED and sends them
+	// back in packs. Orders not sent in a pack when the stream fails are
+	// CREATED again.
 	PackOrders(grpc.BidiStreamingServer[PackOrdersRequest, PackOrdersResponse]) error
+	ShipOrder(context.Context, *ShipOrderRequest) (*ShipOrderResponse, error)
+	// DeliverOrder records the delivery of a SHIPPED order.
+	DeliverOrder(context.Context, *DeliverOrderRequest) (*DeliverOrderResponse, error)
+	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
+	// WatchOrders streams order changes as they happen.
+	WatchOrders(*WatchOrdersRequest, grpc.ServerStreamingServer[OrderEvent]) error
 	mustEmbedUnimplementedOrderManagementServiceServer()
 }
 
@@ -140,12 +212,24 @@ func (UnimplementedOrderManagementServiceServer) CreateOrders(grpc.ClientStreami
 func (UnimplementedOrderManagementServiceServer) GetOrder(context.Context, *wrapperspb.StringValue) (*GetOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
 }
-func (UnimplementedOrderManagementServiceServer) GetOrders(*emptypb.Empty, grpc.ServerStreamingServer[GetOrdersResponse]) error {
+func (UnimplementedOrderManagementServiceServer) GetOrders(*GetOrdersRequest, grpc.ServerStreamingServer[GetOrdersResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method GetOrders not implemented")
 }
 func (UnimplementedOrderManagementServiceServer) PackOrders(grpc.BidiStreamingServer[PackOrdersRequest, PackOrdersResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method PackOrders not implemented")
 }
+func (UnimplementedOrderManagementServiceServer) ShipOrder(context.Context, *ShipOrderRequest) (*ShipOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShipOrder not implemented")
+}
+func (UnimplementedOrderManagementServiceServer) DeliverOrder(context.Context, *DeliverOrderRequest) (*DeliverOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeliverOrder not implemented")
+}
+func (UnimplementedOrderManagementServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedOrderManagementServiceServer) WatchOrders(*WatchOrdersRequest, grpc.ServerStreamingServer[OrderEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOrders not implemented")
+}
 func (UnimplementedOrderManagementServiceServer) mustEmbedUnimplementedOrderManagementServiceServer() {
 }
 func (UnimplementedOrderManagementServiceServer) testEmbeddedByValue() {}
@@ -212,11 +296,11 @@ func _OrderManagementService_GetOrder_Handler(srv interface{}, ctx context.Conte
 }
 
 func _OrderManagementService_GetOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(emptypb.Empty)
+	m := new(GetOrdersRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(OrderManagementServiceServer).GetOrders(m, &grpc.GenericServerStream[emptypb.Empty, GetOrdersResponse]{ServerStream: stream})
+	return srv.(OrderManagementServiceServer).GetOrders(m, &grpc.GenericServerStream[GetOrdersRequest, GetOrdersResponse]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
@@ -229,6 +313,71 @@ func _OrderManagementService_PackOrders_Handler(srv interface{}, stream grpc.Ser
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type OrderManagementService_PackOrdersServer = grpc.BidiStreamingServer[PackOrdersRequest, PackOrdersResponse]
 
+func _OrderManagementService_ShipOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShipOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServiceServer).ShipOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagementService_ShipOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServiceServer).ShipOrder(ctx, req.(*ShipOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagementService_DeliverOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliverOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServiceServer).DeliverOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagementService_DeliverOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServiceServer).DeliverOrder(ctx, req.(*DeliverOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagementService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderManagementServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderManagementService_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderManagementServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderManagementService_WatchOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOrdersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderManagementServiceServer).WatchOrders(m, &grpc.GenericServerStream[WatchOrdersRequest, OrderEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrderManagementService_WatchOrdersServer = grpc.ServerStreamingServer[OrderEvent]
+
 // OrderManagementService_ServiceDesc is the grpc.ServiceDesc for OrderManagementService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -244,6 +393,18 @@ var OrderManagementService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOrder",
 			Handler:    _OrderManagementService_GetOrder_Handler,
 		},
+		{
+			MethodName: "ShipOrder",
+			Handler:    _OrderManagementService_ShipOrder_Handler,
+		},
+		{
+			MethodName: "DeliverOrder",
+			Handler:    _OrderManagementService_DeliverOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _OrderManagementService_CancelOrder_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -262,6 +423,11 @@ var OrderManagementService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "WatchOrders",
+			Handler:       _OrderManagementService_WatchOrders_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "ecommerce/v1/order_management.proto",
 }
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"ch3/svc/pkg/server/interceptors"
+	pb "ch3/svc/protos/ordermgt/v1"
+
+	"golang.org/x/time/rate"
+)
+
+// orderRateLimits builds the rate limits of the OrderManagementService from
+// the -rate-* and -max-streams* flags. The bulk and streaming methods get a
+// tenth of the default call rate, and the orders sent on CreateOrders and
+// PackOrders streams are limited to ten times the default call rate. Health
+// checks are never limited.
+func orderRateLimits() interceptors.RateLimitOptions {
+	calls := interceptors.RateLimit{Rate: rate.Limit(*rateLimit), Burst: *rateBurst}
+	bulkCalls := interceptors.RateLimit{Rate: calls.Rate / 10, Burst: max(calls.Burst/10, 1)}
+	messages := interceptors.RateLimit{Rate: calls.Rate * 10, Burst: calls.Burst * 10}
+	return interceptors.RateLimitOptions{
+		Default: calls,
+		Methods: map[string]interceptors.RateLimit{
+			pb.OrderManagementService_CreateOrders_FullMethodName: bulkCalls,
+			pb.OrderManagementService_PackOrders_FullMethodName:   bulkCalls,
+		},
+		StreamMessages: map[string]interceptors.RateLimit{
+			pb.OrderManagementService_CreateOrders_FullMethodName: messages,
+			pb.OrderManagementService_PackOrders_FullMethodName:   messages,
+		},
+		MaxStreams:          *maxStreams,
+		MaxStreamsPerCaller: *maxStreamsPerCaller,
+		Exempt:              healthMethods,
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"log"
+
+	"ch3/svc/pkg/tlsconfig"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// serverCredentials returns the transport credentials selected with the
+// -tls-* flags: TLS when a certificate is set, mutual TLS when client CAs
+// are set too, and plaintext otherwise.
+func serverCredentials() (credentials.TransportCredentials, error) {
+	if *tlsCert == "" && *tlsKey == "" {
+		if *tlsClientCA != "" {
+			log.Print("Ignoring -tls-client-ca without -tls-cert and -tls-key")
+		}
+		return insecure.NewCredentials(), nil
+	}
+	cfg, err := tlsconfig.Server(tlsconfig.ServerOptions{
+		CertFile:     *tlsCert,
+		KeyFile:      *tlsKey,
+		ClientCAFile: *tlsClientCA,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// productServiceCredentials returns the transport credentials of the
+// ProductInfoService connection: TLS when -product-service-ca is set, with a
+// client certificate when -product-service-cert and -product-service-key are
+// set too, and plaintext otherwise.
+func productServiceCredentials() (credentials.TransportCredentials, error) {
+	if *productServiceCA == "" {
+		return insecure.NewCredentials(), nil
+	}
+	cfg, err := tlsconfig.Client(tlsconfig.ClientOptions{
+		CAFile:   *productServiceCA,
+		CertFile: *productServiceCert,
+		KeyFile:  *productServiceKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(cfg), nil
+}
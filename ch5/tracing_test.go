@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	clientinterceptors "ch3/svc/pkg/client/interceptors"
+	"ch3/svc/pkg/server/interceptors"
+	"ch3/svc/pkg/tracing"
+	pb "ch3/svc/protos/ordermgt/v1"
+	productpb "ch3/svc/protos/product_info/v1"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// productServer prices every product at 5.
+type productServer struct {
+	productpb.UnimplementedProductInfoServiceServer
+}
+
+func (productServer) GetProduct(_ context.Context, id *productpb.ProductID) (*productpb.Product, error) {
+	return &productpb.Product{Id: id.Value, Price: 5}, nil
+}
+
+// keptSpans keeps the exported spans when the tracer provider shuts down,
+// which resets an InMemoryExporter.
+type keptSpans struct {
+	*tracetest.InMemoryExporter
+}
+
+func (keptSpans) Shutdown(context.Context) error { return nil }
+
+// findSpan returns the span of the full method of the given kind.
+func findSpan(t *testing.T, spans tracetest.SpanStubs, fullMethod string, kind trace.SpanKind) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range spans {
+		if span.Name == fullMethod[1:] && span.SpanKind == kind {
+			return span
+		}
+	}
+	t.Fatalf("no %s span for %s", kind, fullMethod)
+	return tracetest.SpanStub{}
+}
+
+// messageEvents counts the message events of span by message type.
+func messageEvents(span tracetest.SpanStub) map[string]int {
+	counts := make(map[string]int)
+	for _, event := range span.Events {
+		for _, attr := range event.Attributes {
+			if attr.Key == semconv.RPCMessageTypeKey {
+				counts[attr.Value.AsString()]++
+			}
+		}
+	}
+	return counts
+}
+
+// TestTracePropagation follows the trace of a CreateOrder from the client
+// to the order service and on to the ProductInfoService, and the one of a
+// PackOrders stream.
+func TestTracePropagation(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	shutdown := tracing.Setup("order-service", keptSpans{exporter})
+
+	products := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors.UnaryServerTracing(interceptors.TracingOptions{})))
+	productpb.RegisterProductInfoServiceServer(products, productServer{})
+	srv := newTestServer()
+	srv.products = productpb.NewProductInfoServiceClient(dialBufconn(t, products,
+		grpc.WithChainUnaryInterceptor(clientinterceptors.UnaryClientTracing(clientinterceptors.TracingOptions{}))))
+
+	orders := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptors.UnaryServerTracing(interceptors.TracingOptions{})),
+		grpc.ChainStreamInterceptor(interceptors.StreamServerTracing(interceptors.TracingOptions{})),
+	)
+	pb.RegisterOrderManagementServiceServer(orders, srv)
+	client := pb.NewOrderManagementServiceClient(dialBufconn(t, orders,
+		grpc.WithChainUnaryInterceptor(clientinterceptors.UnaryClientTracing(clientinterceptors.TracingOptions{})),
+		grpc.WithChainStreamInterceptor(clientinterceptors.StreamClientTracing(clientinterceptors.TracingOptions{})),
+	))
+
+	ctx := context.Background()
+	created, err := client.CreateOrder(ctx, &pb.CreateOrderRequest{
+		Items: []*pb.LineItem{{ProductId: "123e4567-e89b-12d3-a456-426614174000", Quantity: 2}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	stream, err := client.PackOrders(ctx)
+	if err != nil {
+		t.Fatalf("PackOrders: %v", err)
+	}
+	if err := stream.Send(&pb.PackOrdersRequest{Id: created.Id}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	stream.CloseSend()
+	for {
+		if _, err := stream.Recv(); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+	}
+	if err := shutdown(ctx); err != nil {
+		t.Fatalf("failed to flush spans: %v", err)
+	}
+	spans := exporter.GetSpans()
+
+	// Each span is the child of the one of the hop before it.
+	hops := []tracetest.SpanStub{
+		findSpan(t, spans, pb.OrderManagementService_CreateOrder_FullMethodName, trace.SpanKindClient),
+		findSpan(t, spans, pb.OrderManagementService_CreateOrder_FullMethodName, trace.SpanKindServer),
+		findSpan(t, spans, productpb.ProductInfoService_GetProduct_FullMethodName, trace.SpanKindClient),
+		findSpan(t, spans, productpb.ProductInfoService_GetProduct_FullMethodName, trace.SpanKindServer),
+	}
+	traceID := hops[0].SpanContext.TraceID()
+	for i, span := range hops[1:] {
+		if span.SpanContext.TraceID() != traceID {
+			t.Errorf("span %s of kind %s is in trace %s, want %s", span.Name, span.SpanKind, span.SpanContext.TraceID(), traceID)
+		}
+		if parent := hops[i].SpanContext.SpanID(); span.Parent.SpanID() != parent {
+			t.Errorf("span %s of kind %s has parent %s, want %s", span.Name, span.SpanKind, span.Parent.SpanID(), parent)
+		}
+	}
+
+	clientSpan := findSpan(t, spans, pb.OrderManagementService_PackOrders_FullMethodName, trace.SpanKindClient)
+	serverSpan := findSpan(t, spans, pb.OrderManagementService_PackOrders_FullMethodName, trace.SpanKindServer)
+	if serverSpan.SpanContext.TraceID() != clientSpan.SpanContext.TraceID() {
+		t.Error("PackOrders client and server spans are in different traces")
+	}
+	want := map[string]int{"SENT": 1, "RECEIVED": 1}
+	for _, span := range []tracetest.SpanStub{clientSpan, serverSpan} {
+		if got := messageEvents(span); got["SENT"] != want["SENT"] || got["RECEIVED"] != want["RECEIVED"] {
+			t.Errorf("%s span message events = %v, want %v", span.SpanKind, got, want)
+		}
+	}
+}